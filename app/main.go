@@ -1,16 +1,29 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+	_ "time/tzdata"
 
 	"github.com/J0hnLenin/ReviewRequest/internal/api/handler"
+	"github.com/J0hnLenin/ReviewRequest/internal/logging"
+	"github.com/J0hnLenin/ReviewRequest/internal/metrics"
 	"github.com/J0hnLenin/ReviewRequest/internal/repository/postgres"
 	"github.com/J0hnLenin/ReviewRequest/service"
 )
 
 func main() {
+    slog.SetDefault(logging.NewJSONLogger(os.Stdout, logLevel()))
+
     connStr := os.Getenv("DATABASE_URL");
     if connStr == "" {
         panic("Connection string empty")
@@ -18,25 +31,171 @@ func main() {
 
     repo, err := postgres.NewPostgresRepository(connStr)
     if err != nil {
-        log.Fatalf("Failed to connect to database: %v", err)
+        slog.Error("Failed to connect to database", "error", err)
+        os.Exit(1)
     }
     defer repo.Close()
 
-    svc := service.NewService(repo)
+    registry := metrics.NewRegistry()
+    repo.Instrument(registry)
+
+    svc := service.NewService(repo, append(serviceOptions(), service.WithMetrics(newServiceMetrics(registry)))...)
+    registerLoadGauges(registry, svc)
 
     h := handler.NewHandler(svc)
 
     http.HandleFunc("/team/add", h.TeamAdd)
     http.HandleFunc("/team/get", h.TeamGet)
     http.HandleFunc("/team/setIsActive", h.TeamSetIsActive)
+    http.HandleFunc("/team/codeowners", h.TeamSetCodeowners)
+    http.HandleFunc("/team/setRoleRequirements", h.TeamSetRoleRequirements)
+    http.HandleFunc("/team/setSLA", h.TeamSetSLA)
     http.HandleFunc("/users/setIsActive", h.UserSetIsActive)
+    http.HandleFunc("/users/setWorkingHours", h.UserSetWorkingHours)
+    http.HandleFunc("/users/setReviewWeight", h.UserSetReviewWeight)
     http.HandleFunc("/pullRequest/create", h.PRCreate)
+    http.HandleFunc("/pullRequest/simulate", h.PRSimulate)
     http.HandleFunc("/pullRequest/merge", h.PRMerge)
     http.HandleFunc("/pullRequest/reassign", h.PRReassign)
+    http.HandleFunc("/pullRequest/addReviewer", h.PRAddReviewer)
+    http.HandleFunc("/pullRequest/removeReviewer", h.PRRemoveReviewer)
+    http.HandleFunc("/pullRequest/decline", h.PRDecline)
+    http.HandleFunc("/pullRequest/fill", h.PRFill)
+    http.HandleFunc("/pullRequest/review", h.PRReview)
+    http.HandleFunc("/pullRequest/list", h.PRList)
     http.HandleFunc("/users/getReview", h.UserGetReviews)
+    http.HandleFunc("/users/stats", h.UserGetStats)
     http.HandleFunc("/health", h.HealthCheck)
     http.HandleFunc("/statistics", h.GetStatistics)
+    http.HandleFunc("/statistics/team", h.GetTeamStatistics)
+    http.HandleFunc("/statistics/latency", h.GetLatencyStatistics)
+    http.HandleFunc("/statistics/leaderboard", h.GetLeaderboard)
+    http.HandleFunc("/statistics/timeseries", h.GetTimeSeries)
+    http.Handle("/metrics", registry.Handler())
+
+    ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+    defer stop()
+
+    var workers sync.WaitGroup
+    workers.Add(2)
+    go func() {
+        defer workers.Done()
+        svc.RunSLAWorker(ctx, slaCheckInterval())
+    }()
+    go func() {
+        defer workers.Done()
+        svc.RunTopUpWorker(ctx)
+    }()
+
+    server := &http.Server{
+        Addr:    ":8080",
+        Handler: logging.Middleware(metrics.NewHTTP(registry).Wrap(http.DefaultServeMux)),
+    }
+    go func() {
+        slog.Info("Server starting", "addr", server.Addr)
+        if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+            slog.Error("Server error", "error", err)
+            stop()
+        }
+    }()
+
+    <-ctx.Done()
+    slog.Info("Shutting down")
+
+    shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+    defer cancel()
+    if err := server.Shutdown(shutdownCtx); err != nil {
+        slog.Error("Server shutdown error", "error", err)
+    }
+    workers.Wait()
+}
+
+const (
+    shutdownTimeout         = 10 * time.Second
+    defaultSLACheckInterval = time.Minute
+)
+
+// serviceMetrics records the service's assignment counters in Prometheus.
+type serviceMetrics struct {
+    assignments   *metrics.CounterVec
+    reassignments *metrics.CounterVec
+}
+
+func newServiceMetrics(reg *metrics.Registry) *serviceMetrics {
+    return &serviceMetrics{
+        assignments: reg.Counter("review_assignments_total",
+            "Reviewer assignments on PR creation by outcome.", "outcome"),
+        reassignments: reg.Counter("review_reassignments_total",
+            "Reviewer reassignments, with a requested reviewer or picked automatically.", "mode"),
+    }
+}
+
+func (m *serviceMetrics) AssignmentOutcome(outcome string) {
+    m.assignments.Inc(outcome)
+}
+
+func (m *serviceMetrics) Reassignment(mode string) {
+    m.reassignments.Inc(mode)
+}
+
+// registerLoadGauges refreshes the open PR and team load gauges on every scrape.
+func registerLoadGauges(reg *metrics.Registry, svc *service.Service) {
+    openPRs := reg.Gauge("review_open_prs", "Pull requests that are not merged.")
+    teamLoad := reg.Gauge("review_team_open_reviews", "Open reviews assigned to the members of a team.", "team")
+    reg.OnScrape(func(ctx context.Context) error {
+        load, err := svc.ReviewLoad(ctx)
+        if err != nil {
+            return err
+        }
+        openPRs.Set(float64(load.OpenPRs))
+        teamLoad.Reset()
+        for team, count := range load.TeamReviews {
+            teamLoad.Set(float64(count), team)
+        }
+        return nil
+    })
+}
 
-    log.Println("Server starting on :8080")
-    log.Fatal(http.ListenAndServe(":8080", nil))
-}
\ No newline at end of file
+// serviceOptions reads ASSIGNMENT_SEED to make reviewer picks reproducible,
+// e.g. when replaying an incident.
+func serviceOptions() []service.Option {
+    value := os.Getenv("ASSIGNMENT_SEED")
+    if value == "" {
+        return nil
+    }
+    seed, err := strconv.ParseInt(value, 10, 64)
+    if err != nil {
+        slog.Error("Invalid ASSIGNMENT_SEED", "value", value, "error", err)
+        os.Exit(1)
+    }
+    slog.Info("Reviewer assignment seeded", "seed", seed)
+    return []service.Option{service.WithSeed(seed)}
+}
+
+// slaCheckInterval reads SLA_CHECK_INTERVAL (e.g. "30s", "5m").
+func slaCheckInterval() time.Duration {
+    value := os.Getenv("SLA_CHECK_INTERVAL")
+    if value == "" {
+        return defaultSLACheckInterval
+    }
+    interval, err := time.ParseDuration(value)
+    if err != nil || interval <= 0 {
+        slog.Warn("Invalid SLA_CHECK_INTERVAL, using default", "value", value, "default", defaultSLACheckInterval.String())
+        return defaultSLACheckInterval
+    }
+    return interval
+}
+
+// logLevel reads LOG_LEVEL (debug, info, warn or error).
+func logLevel() slog.Level {
+    var level slog.Level
+    value := os.Getenv("LOG_LEVEL")
+    if value == "" {
+        return slog.LevelInfo
+    }
+    if err := level.UnmarshalText([]byte(value)); err != nil {
+        fmt.Fprintf(os.Stderr, "Invalid LOG_LEVEL %q, using info\n", value)
+        return slog.LevelInfo
+    }
+    return level
+}
@@ -9,4 +9,26 @@ var (
 	ErrPRMerged		 = errors.New("cannot reassign on merged PR")
 	ErrNotAssigned   = errors.New("reviewer is not assigned to this PR")
 	ErrNoCandidate   = errors.New("no active replacement candidate in team")
-)
\ No newline at end of file
+	ErrInvalidStrategy     = errors.New("unknown assignment strategy")
+	ErrInvalidWorkingHours = errors.New("invalid time zone or working hours")
+	ErrInvalidCodeowners   = errors.New("invalid CODEOWNERS file")
+	ErrInvalidRole         = errors.New("unknown reviewer role")
+	ErrInvalidRequirements = errors.New("invalid reviewer role requirements")
+	ErrRoleRequirement     = errors.New("reviewer role requirement cannot be met")
+	ErrReviewerLimit       = errors.New("PR already has the maximum number of reviewers")
+	ErrNotTeamMember       = errors.New("reviewer is not a member of the team this slot belongs to")
+	ErrReviewerIsAuthor    = errors.New("author cannot review own PR")
+	ErrAlreadyAssigned     = errors.New("reviewer is already assigned to this PR")
+	ErrReviewerInactive    = errors.New("reviewer is not active")
+	ErrReviewerDeclined    = errors.New("reviewer declined this PR")
+	ErrInvalidReason       = errors.New("decline reason must be between 1 and 255 characters")
+	ErrInvalidSLA          = errors.New("invalid review SLA policy")
+	ErrInvalidSimulation   = errors.New("simulation runs must be between 1 and 10000")
+	ErrInvalidWeight       = errors.New("review weight must be between 0 and 100")
+	ErrInvalidExtraTeams   = errors.New("invalid extra reviewer teams")
+	ErrInvalidTimeRange    = errors.New("invalid statistics time range")
+	ErrInvalidDecision     = errors.New("review decision must be approved or changes_requested")
+	ErrInvalidLeaderboard  = errors.New("invalid leaderboard metric or page")
+	ErrInvalidTimeSeries   = errors.New("invalid time series metric or bucket")
+	ErrInvalidPRFilter     = errors.New("invalid pull request filter")
+)
@@ -3,15 +3,87 @@ package domain
 import "time"
 
 type User struct {
-	ID       string
-	Name     string
-	TeamName string
-	IsActive bool
+	ID           string
+	Name         string
+	TeamName     string
+	IsActive     bool
+	WorkingHours WorkingHours
+	Tags         []string
+	Role         Role
+	// ReviewWeight scales how often the user is picked by the weighted strategy.
+	// Nil means DefaultReviewWeight; 0 excludes the user from automatic assignment.
+	ReviewWeight *int
+}
+
+const (
+	DefaultReviewWeight = 1
+	MaxReviewWeight     = 100
+)
+
+type Role string
+
+const (
+	RoleJunior Role = "junior"
+	RoleMiddle Role = "middle"
+	RoleSenior Role = "senior"
+	RoleLead   Role = "lead"
+)
+
+// WorkingHours is a daily window in the user's time zone. Start and End are
+// minutes after local midnight; End may be less than Start for night shifts.
+// An empty TimeZone means the user has no configured hours.
+type WorkingHours struct {
+	TimeZone string
+	Start    int
+	End      int
 }
 
 type Team struct {
-	Name    string
-	Members []*User
+	Name             string
+	Members          []*User
+	OwnerRules       []OwnerRule
+	RoleRequirements []RoleRequirement
+	SLA              SLAPolicy
+	// RoundRobinCursor is the id of the member picked last by the round-robin strategy.
+	RoundRobinCursor string
+}
+
+type SLAAction string
+
+const (
+	SLANotify      SLAAction = "notify"
+	SLAAddReviewer SLAAction = "add_reviewer"
+	SLAReassign    SLAAction = "reassign"
+)
+
+// SLAPolicy says how long a review may stay pending before the SLA worker
+// escalates it with Action. Zero Hours disables escalation for the team.
+type SLAPolicy struct {
+	Hours  int
+	Action SLAAction
+}
+
+// PendingReview is a reviewer assignment on an open PR that is still waiting.
+type PendingReview struct {
+	PullRequestID string
+	ReviewerID    string
+	AssignedAt    time.Time
+}
+
+// RoleRequirement asks for at least Count reviewers of MinRole or above.
+// Each reviewer counts towards one requirement only.
+type RoleRequirement struct {
+	MinRole Role
+	Count   int
+}
+
+// OwnerRule maps a CODEOWNERS-style path pattern to the users and
+// expertise tags that own matching files. As in CODEOWNERS, the last
+// matching rule wins.
+type OwnerRule struct {
+	Pattern string
+	UserIDs []string
+	Tags    []string
 }
 
 type PullRequest struct {
@@ -21,6 +93,94 @@ type PullRequest struct {
 	ReviewersID []string
 	Status      PRStatus
 	MergedAt    *time.Time
+	CreatedAt   time.Time
+	// ReviewerTeams maps each reviewer to the team they represent on this PR.
+	ReviewerTeams map[string]string
+	// ExtraTeams are the teams asked to provide one reviewer each besides the author's team.
+	ExtraTeams []string
+	// Paths are the changed files given at creation; path ownership keeps
+	// applying to every later pick for the PR.
+	Paths []string
+	// DeclinedIDs holds reviewers who declined this PR and are never auto-picked for it again.
+	DeclinedIDs []string
+	// Explanation is set only by the call that picked reviewers and is not persisted.
+	Explanation *AssignmentExplanation
+}
+
+// Simulation is the outcome of assignment dry runs: the reviewers the first
+// run picked and how often each user was picked across all runs. RoleGap
+// describes the role requirement the first run left unmet, if any.
+type Simulation struct {
+	Reviewers   []string
+	Explanation *AssignmentExplanation
+	RoleGap     string
+	Runs        int
+	Counts      map[string]int
+}
+
+type ExclusionReason string
+
+const (
+	ExcludedAuthor          ExclusionReason = "author"
+	ExcludedInactive        ExclusionReason = "inactive"
+	ExcludedAlreadyAssigned ExclusionReason = "already_assigned"
+	ExcludedDeclined        ExclusionReason = "declined"
+	ExcludedZeroWeight      ExclusionReason = "zero_weight"
+	ExcludedOffHours        ExclusionReason = "outside_working_hours"
+	ExcludedNotOwner        ExclusionReason = "not_owner"
+	ExcludedRoleRequirement ExclusionReason = "role_requirement"
+	// ExcludedAtCapacity marks eligible members whose team already had all of
+	// its reviewer slots taken, so no pick from the team was made.
+	ExcludedAtCapacity ExclusionReason = "at_capacity"
+	// ExcludedNotPicked marks eligible members never weighed by any pick.
+	ExcludedNotPicked ExclusionReason = "not_picked"
+)
+
+type Exclusion struct {
+	UserID string
+	Reason ExclusionReason
+}
+
+// AssignmentExplanation tells why reviewers were picked: the eligible
+// candidate pool, who was left out and why, and the strategy used.
+type AssignmentExplanation struct {
+	Strategy   AssignmentStrategy
+	Candidates []string
+	Excluded   []Exclusion
+}
+
+type ReviewerAction string
+
+const (
+	ReviewerAssigned  ReviewerAction = "assigned"
+	ReviewerAdded     ReviewerAction = "added"
+	ReviewerRemoved   ReviewerAction = "removed"
+	ReviewerReplaced  ReviewerAction = "replaced"
+	ReviewerDeclined  ReviewerAction = "declined"
+	ReviewerEscalated ReviewerAction = "escalated"
+
+	ReviewerApproved         ReviewerAction = "approved"
+	ReviewerChangesRequested ReviewerAction = "changes_requested"
+)
+
+// ReviewerEvent records a single change of a PR's reviewer set.
+type ReviewerEvent struct {
+	PullRequestID string
+	UserID        string
+	Action        ReviewerAction
+	Reason        string
+	CreatedAt     time.Time
+}
+
+// PRFilter selects PRs for listing; empty fields match any PR.
+type PRFilter struct {
+	Status     *PRStatus
+	AuthorID   string
+	TeamName   string
+	ReviewerID string
+	Period     TimeRange
+	Limit      int
+	Offset     int
 }
 
 type PRStatus bool
@@ -34,16 +194,193 @@ const (
 	MaxReviewers = 2
 )
 
+type AssignmentStrategy string
+
+const (
+	StrategyRandom        AssignmentStrategy = "random"
+	StrategyWorkingHours  AssignmentStrategy = "working_hours"
+	StrategyPairAvoidance AssignmentStrategy = "pair_avoidance"
+	StrategyWeighted      AssignmentStrategy = "weighted"
+	StrategyRoundRobin    AssignmentStrategy = "round_robin"
+)
+
+type AssignmentOptions struct {
+	Strategy       AssignmentStrategy
+	WithinHours    int
+	PairWindowDays int
+	Paths          []string
+	// ExtraTeams asks for one reviewer from each listed team; the author's
+	// team then provides a single reviewer.
+	ExtraTeams []string
+}
+
+const MaxExtraTeams = 3
+
 type Statistics struct {
 	TotalOpenPRs     int          `json:"total_open_prs"`
 	TotalClosedPRs   int          `json:"total_closed_prs"`
 	TopOpenReviewer  *UserStats   `json:"top_open_reviewer,omitempty"`
 	TopClosedReviewer *UserStats  `json:"top_closed_reviewer,omitempty"`
 	TopAuthor        *UserStats   `json:"top_author,omitempty"`
+	LoadDistribution *LoadDistribution `json:"load_distribution,omitempty"`
+}
+
+// LoadDistribution describes how open reviews are spread over active users.
+// Gini is 0 when everyone has the same load and approaches 1 when a single
+// user has all of it.
+type LoadDistribution struct {
+	Users     int          `json:"users"`
+	Histogram []LoadBucket `json:"histogram"`
+	P50       float64      `json:"p50"`
+	P90       float64      `json:"p90"`
+	P99       float64      `json:"p99"`
+	Max       int          `json:"max"`
+	Gini      float64      `json:"gini"`
+}
+
+// LoadBucket counts the active users that have exactly OpenReviews open reviews.
+type LoadBucket struct {
+	OpenReviews int `json:"open_reviews"`
+	Users       int `json:"users"`
+}
+
+// TimeRange limits statistics to [From, To). A nil bound leaves that side open.
+type TimeRange struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// PRTimeline holds the timestamps latency statistics are computed from.
+// FirstReviewAt is the earliest review decision by any reviewer.
+type PRTimeline struct {
+	PullRequestID string
+	TeamName      string
+	CreatedAt     time.Time
+	FirstReviewAt *time.Time
+	MergedAt      *time.Time
+	Reviews       []ReviewTiming
+}
+
+// ReviewTiming is a reviewer's first decision on a PR and when they were assigned to it.
+type ReviewTiming struct {
+	ReviewerID string
+	AssignedAt time.Time
+	DecidedAt  time.Time
+}
+
+// LatencyPercentiles summarises Count durations, in seconds.
+type LatencyPercentiles struct {
+	Count int     `json:"count"`
+	P50   float64 `json:"p50_seconds"`
+	P90   float64 `json:"p90_seconds"`
+	P99   float64 `json:"p99_seconds"`
+}
+
+type ReviewLatency struct {
+	TimeToFirstReview LatencyPercentiles `json:"time_to_first_review"`
+	TimeToMerge       LatencyPercentiles `json:"time_to_merge"`
+}
+
+type LatencyStatistics struct {
+	Global    ReviewLatency            `json:"global"`
+	Teams     map[string]ReviewLatency `json:"teams"`
+	Reviewers map[string]ReviewLatency `json:"reviewers"`
+}
+
+// UserProfile is a user's activity as an author and as a reviewer. Count
+// is every review in the period, open or completed.
+// AvgTimeToMergeSeconds is nil until a PR the user reviewed is merged.
+type UserProfile struct {
+	UserStats
+	AuthoredOpen          int      `json:"authored_open"`
+	AuthoredMerged        int      `json:"authored_merged"`
+	ReviewsOpen           int      `json:"reviews_open"`
+	ReviewsCompleted      int      `json:"reviews_completed"`
+	ReassignedAway        int      `json:"reassigned_away"`
+	Declined              int      `json:"declined"`
+	AvgTimeToMergeSeconds *float64 `json:"avg_time_to_merge_seconds,omitempty"`
+}
+
+type TimeBucket string
+
+const (
+	BucketDay   TimeBucket = "day"
+	BucketWeek  TimeBucket = "week"
+	BucketMonth TimeBucket = "month"
+)
+
+type TimeSeriesMetric string
+
+const (
+	SeriesPRsCreated      TimeSeriesMetric = "prs_created"
+	SeriesPRsMerged       TimeSeriesMetric = "prs_merged"
+	SeriesReviewsAssigned TimeSeriesMetric = "reviews_assigned"
+)
+
+// TimeSeriesPoint counts events in the bucket starting at Start (UTC).
+type TimeSeriesPoint struct {
+	Start time.Time `json:"start"`
+	Count int       `json:"count"`
+}
+
+type TimeSeries struct {
+	Bucket   TimeBucket                             `json:"bucket"`
+	TeamName string                                 `json:"team_name,omitempty"`
+	Series   map[TimeSeriesMetric][]TimeSeriesPoint `json:"series"`
+}
+
+type LeaderboardMetric string
+
+const (
+	LeaderboardOpenReviews   LeaderboardMetric = "open_reviews"
+	LeaderboardClosedReviews LeaderboardMetric = "closed_reviews"
+	LeaderboardAuthored      LeaderboardMetric = "authored"
+)
+
+// LeaderboardEntry ranks a user by Count. Users with equal counts share a
+// rank and the next rank skips accordingly (1, 1, 3).
+type LeaderboardEntry struct {
+	Rank int `json:"rank"`
+	UserStats
+}
+
+// Leaderboard is one page of a ranking; Total counts all ranked users.
+type Leaderboard struct {
+	Metric  LeaderboardMetric  `json:"metric"`
+	Total   int                `json:"total"`
+	Limit   int                `json:"limit"`
+	Offset  int                `json:"offset"`
+	Entries []LeaderboardEntry `json:"entries"`
 }
 
 type UserStats struct {
 	UserID   string `json:"user_id"`
 	UserName string `json:"user_name"`
 	Count    int    `json:"count"`
-}
\ No newline at end of file
+}
+
+// TeamStatistics counts PRs authored in the team and the open reviews
+// assigned to each of its members.
+type TeamStatistics struct {
+	TeamName            string           `json:"team_name"`
+	OpenPRs             int              `json:"open_prs"`
+	MergedPRs           int              `json:"merged_prs"`
+	MemberLoad          []MemberLoad     `json:"member_load"`
+	AvgReviewsPerMember float64          `json:"avg_reviews_per_member"`
+	MostLoaded          *UserStats       `json:"most_loaded,omitempty"`
+	LeastLoaded         *UserStats       `json:"least_loaded,omitempty"`
+	LoadDistribution    LoadDistribution `json:"load_distribution"`
+}
+
+// MemberLoad is the number of open reviews assigned to a team member.
+type MemberLoad struct {
+	UserStats
+	IsActive bool `json:"is_active"`
+}
+
+// ReviewLoad is the current number of open PRs and of open reviews
+// assigned to the members of each team.
+type ReviewLoad struct {
+	OpenPRs     int
+	TeamReviews map[string]int
+}
@@ -2,7 +2,8 @@ package handler
 
 import (
 	"encoding/json"
-	"log"
+	"errors"
+	"log/slog"
 	"net/http"
 
 	"github.com/J0hnLenin/ReviewRequest/domain"
@@ -15,7 +16,7 @@ type ErrorResponse struct {
 	} `json:"error"`
 }
 
-func (h *Handler) writeError(w http.ResponseWriter, status int, code, message string) {
+func (h *Handler) writeError(w http.ResponseWriter, r *http.Request, status int, code, message string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 
@@ -25,25 +26,70 @@ func (h *Handler) writeError(w http.ResponseWriter, status int, code, message st
 
 	err := json.NewEncoder(w).Encode(errorResp)
 	if err != nil {
-		log.Printf("response encode error: %v", err)
+		slog.ErrorContext(r.Context(), "response encode error", "error", err)
 	}
 }
 
-func (h *Handler) handleError(w http.ResponseWriter, err error) {
-	switch err {
-	case domain.ErrNotFound:
-		h.writeError(w, http.StatusNotFound, "NOT_FOUND", err.Error())
-	case domain.ErrTeamExists:
-		h.writeError(w, http.StatusBadRequest, "TEAM_EXISTS", err.Error())
-	case domain.ErrPRExists:
-		h.writeError(w, http.StatusConflict, "PR_EXISTS", err.Error())
-	case domain.ErrPRMerged:
-		h.writeError(w, http.StatusConflict, "PR_MERGED", err.Error())
-	case domain.ErrNotAssigned:
-		h.writeError(w, http.StatusConflict, "NOT_ASSIGNED", err.Error())
-	case domain.ErrNoCandidate:
-		h.writeError(w, http.StatusConflict, "NO_CANDIDATE", err.Error())
+func (h *Handler) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		h.writeError(w, r, http.StatusNotFound, "NOT_FOUND", err.Error())
+	case errors.Is(err, domain.ErrTeamExists):
+		h.writeError(w, r, http.StatusBadRequest, "TEAM_EXISTS", err.Error())
+	case errors.Is(err, domain.ErrPRExists):
+		h.writeError(w, r, http.StatusConflict, "PR_EXISTS", err.Error())
+	case errors.Is(err, domain.ErrPRMerged):
+		h.writeError(w, r, http.StatusConflict, "PR_MERGED", err.Error())
+	case errors.Is(err, domain.ErrNotAssigned):
+		h.writeError(w, r, http.StatusConflict, "NOT_ASSIGNED", err.Error())
+	case errors.Is(err, domain.ErrNoCandidate):
+		h.writeError(w, r, http.StatusConflict, "NO_CANDIDATE", err.Error())
+	case errors.Is(err, domain.ErrInvalidStrategy):
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_STRATEGY", err.Error())
+	case errors.Is(err, domain.ErrInvalidWorkingHours):
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_WORKING_HOURS", err.Error())
+	case errors.Is(err, domain.ErrInvalidCodeowners):
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_CODEOWNERS", err.Error())
+	case errors.Is(err, domain.ErrInvalidRole):
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_ROLE", err.Error())
+	case errors.Is(err, domain.ErrInvalidRequirements):
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_REQUIREMENTS", err.Error())
+	case errors.Is(err, domain.ErrRoleRequirement):
+		h.writeError(w, r, http.StatusConflict, "ROLE_REQUIREMENT_UNMET", err.Error())
+	case errors.Is(err, domain.ErrReviewerLimit):
+		h.writeError(w, r, http.StatusConflict, "REVIEWER_LIMIT", err.Error())
+	case errors.Is(err, domain.ErrNotTeamMember):
+		h.writeError(w, r, http.StatusConflict, "NOT_TEAM_MEMBER", err.Error())
+	case errors.Is(err, domain.ErrReviewerIsAuthor):
+		h.writeError(w, r, http.StatusConflict, "REVIEWER_IS_AUTHOR", err.Error())
+	case errors.Is(err, domain.ErrAlreadyAssigned):
+		h.writeError(w, r, http.StatusConflict, "ALREADY_ASSIGNED", err.Error())
+	case errors.Is(err, domain.ErrReviewerInactive):
+		h.writeError(w, r, http.StatusConflict, "REVIEWER_INACTIVE", err.Error())
+	case errors.Is(err, domain.ErrReviewerDeclined):
+		h.writeError(w, r, http.StatusConflict, "REVIEWER_DECLINED", err.Error())
+	case errors.Is(err, domain.ErrInvalidReason):
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_REASON", err.Error())
+	case errors.Is(err, domain.ErrInvalidSLA):
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_SLA", err.Error())
+	case errors.Is(err, domain.ErrInvalidSimulation):
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_SIMULATION", err.Error())
+	case errors.Is(err, domain.ErrInvalidWeight):
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_WEIGHT", err.Error())
+	case errors.Is(err, domain.ErrInvalidExtraTeams):
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_EXTRA_TEAMS", err.Error())
+	case errors.Is(err, domain.ErrInvalidTimeRange):
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_TIME_RANGE", err.Error())
+	case errors.Is(err, domain.ErrInvalidDecision):
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_DECISION", err.Error())
+	case errors.Is(err, domain.ErrInvalidLeaderboard):
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_LEADERBOARD", err.Error())
+	case errors.Is(err, domain.ErrInvalidTimeSeries):
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_TIMESERIES", err.Error())
+	case errors.Is(err, domain.ErrInvalidPRFilter):
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_FILTER", err.Error())
 	default:
-		h.writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+		slog.ErrorContext(r.Context(), "internal error", "error", err)
+		h.writeError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
 	}
 }
@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/J0hnLenin/ReviewRequest/domain"
+)
+
+type format string
+
+const (
+	formatJSON   format = "json"
+	formatCSV    format = "csv"
+	formatNDJSON format = "ndjson"
+)
+
+// responseFormat takes the format from the format query parameter or, when it
+// is absent, from the Accept header. ok is false for an unknown format.
+func responseFormat(r *http.Request) (f format, ok bool) {
+	switch f := format(r.URL.Query().Get("format")); f {
+	case formatJSON, formatCSV, formatNDJSON:
+		return f, true
+	case "":
+	default:
+		return "", false
+	}
+
+	accept := r.Header.Get("Accept")
+	switch {
+	case strings.Contains(accept, "text/csv"):
+		return formatCSV, true
+	case strings.Contains(accept, "application/x-ndjson"), strings.Contains(accept, "application/ndjson"):
+		return formatNDJSON, true
+	}
+	return formatJSON, true
+}
+
+func (h *Handler) writeFormatError(w http.ResponseWriter, r *http.Request) {
+	h.writeError(w, r, http.StatusBadRequest, "INVALID_FORMAT", "format must be json, csv or ndjson")
+}
+
+// table is a response exported as CSV with a header row, or as NDJSON with
+// one object per row keyed by column.
+type table struct {
+	columns []string
+	rows    [][]interface{}
+}
+
+func (h *Handler) writeTable(w http.ResponseWriter, r *http.Request, f format, t table) {
+	switch f {
+	case formatCSV:
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		cw := csv.NewWriter(w)
+		if err := cw.Write(t.columns); err != nil {
+			slog.ErrorContext(r.Context(), "response encode error", "error", err)
+			return
+		}
+		record := make([]string, len(t.columns))
+		for _, row := range t.rows {
+			for i, value := range row {
+				record[i] = csvValue(value)
+			}
+			if err := cw.Write(record); err != nil {
+				slog.ErrorContext(r.Context(), "response encode error", "error", err)
+				return
+			}
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			slog.ErrorContext(r.Context(), "response encode error", "error", err)
+		}
+	case formatNDJSON:
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		enc := json.NewEncoder(w)
+		for _, row := range t.rows {
+			object := make(map[string]interface{}, len(t.columns))
+			for i, column := range t.columns {
+				object[column] = row[i]
+			}
+			if err := enc.Encode(object); err != nil {
+				slog.ErrorContext(r.Context(), "response encode error", "error", err)
+				return
+			}
+		}
+	}
+}
+
+func csvValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return csvText(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []string:
+		return csvText(strings.Join(v, ";"))
+	}
+	return fmt.Sprint(value)
+}
+
+// csvText quotes text that a spreadsheet would otherwise run as a formula,
+// such as a PR title or user name starting with "=".
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func prTable(prs []*domain.PullRequest) table {
+	t := table{
+		columns: []string{"pull_request_id", "pull_request_name", "author_id", "status", "assigned_reviewers", "createdAt", "mergedAt"},
+		rows:    make([][]interface{}, len(prs)),
+	}
+	for i, pr := range prs {
+		status := "OPEN"
+		if pr.Status == domain.Merged {
+			status = "MERGED"
+		}
+		var mergedAt interface{}
+		if pr.MergedAt != nil {
+			mergedAt = pr.MergedAt.Format(time.RFC3339)
+		}
+		var createdAt interface{}
+		if !pr.CreatedAt.IsZero() {
+			createdAt = pr.CreatedAt.Format(time.RFC3339)
+		}
+		reviewers := pr.ReviewersID
+		if reviewers == nil {
+			reviewers = []string{}
+		}
+		t.rows[i] = []interface{}{pr.ID, pr.Title, pr.AuthorID, status, reviewers, createdAt, mergedAt}
+	}
+	return t
+}
+
+func statisticsTable(stats *domain.Statistics) table {
+	t := table{
+		columns: []string{"metric", "user_id", "user_name", "value"},
+		rows: [][]interface{}{
+			{"total_open_prs", nil, nil, stats.TotalOpenPRs},
+			{"total_closed_prs", nil, nil, stats.TotalClosedPRs},
+		},
+	}
+	tops := []struct {
+		metric string
+		user   *domain.UserStats
+	}{
+		{"top_open_reviewer", stats.TopOpenReviewer},
+		{"top_closed_reviewer", stats.TopClosedReviewer},
+		{"top_author", stats.TopAuthor},
+	}
+	for _, top := range tops {
+		if top.user != nil {
+			t.rows = append(t.rows, []interface{}{top.metric, top.user.UserID, top.user.UserName, top.user.Count})
+		}
+	}
+	if load := stats.LoadDistribution; load != nil {
+		t.rows = append(t.rows,
+			[]interface{}{"load_users", nil, nil, load.Users},
+			[]interface{}{"load_p50", nil, nil, load.P50},
+			[]interface{}{"load_p90", nil, nil, load.P90},
+			[]interface{}{"load_p99", nil, nil, load.P99},
+			[]interface{}{"load_max", nil, nil, load.Max},
+			[]interface{}{"load_gini", nil, nil, load.Gini},
+		)
+	}
+	return t
+}
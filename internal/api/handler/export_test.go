@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSVValue_EscapesFormulas(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    interface{}
+		expected string
+	}{
+		{"Equals", "=HYPERLINK(\"http://evil\")", "'=HYPERLINK(\"http://evil\")"},
+		{"Plus", "+1+1", "'+1+1"},
+		{"Minus", "-2+3", "'-2+3"},
+		{"At", "@SUM(A1)", "'@SUM(A1)"},
+		{"Tab", "\t=1", "'\t=1"},
+		{"Carriage return", "\r=1", "'\r=1"},
+		{"Reviewer list", []string{"=evil", "u2"}, "'=evil;u2"},
+		{"Plain text", "Fix login", "Fix login"},
+		{"Inner sign", "a=b", "a=b"},
+		{"Empty", "", ""},
+		{"Negative number", -1.5, "-1.5"},
+		{"Nil", nil, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, csvValue(tc.value))
+		})
+	}
+}
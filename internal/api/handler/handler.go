@@ -2,7 +2,7 @@ package handler
 
 import (
 	"encoding/json"
-	"log"
+	"log/slog"
 	"net/http"
 
 	"github.com/J0hnLenin/ReviewRequest/service"
@@ -23,6 +23,6 @@ func (h *Handler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 		"status": "healthy",
 	})
 	if err != nil {
-		log.Printf("response encode error: %v", err)
+		slog.ErrorContext(r.Context(), "response encode error", "error", err)
 	}
 }
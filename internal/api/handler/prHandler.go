@@ -2,52 +2,170 @@ package handler
 
 import (
 	"encoding/json"
-	"log"
+	"errors"
+	"log/slog"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/J0hnLenin/ReviewRequest/domain"
+	"github.com/J0hnLenin/ReviewRequest/service"
 )
 
 func (h *Handler) PRCreate(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		h.writeError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
 		return
 	}
 
 	var req struct {
-		PullRequestID   string `json:"pull_request_id"`
-		PullRequestName string `json:"pull_request_name"`
-		AuthorID        string `json:"author_id"`
+		PullRequestID   string   `json:"pull_request_id"`
+		PullRequestName string   `json:"pull_request_name"`
+		AuthorID        string   `json:"author_id"`
+		Strategy        string   `json:"strategy"`
+		WithinHours     int      `json:"within_hours"`
+		PairWindowDays  int      `json:"pair_window_days"`
+		Paths           []string `json:"paths"`
+		ExtraTeams      []string `json:"extra_teams"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
 		return
 	}
 
-	pr, err := h.service.PRCreate(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID)
+	opts := domain.AssignmentOptions{
+		Strategy:       domain.AssignmentStrategy(req.Strategy),
+		WithinHours:    req.WithinHours,
+		PairWindowDays: req.PairWindowDays,
+		Paths:          req.Paths,
+		ExtraTeams:     req.ExtraTeams,
+	}
+
+	pr, err := h.service.PRCreate(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID, opts)
+	roleGap := ""
+	if errors.Is(err, domain.ErrRoleRequirement) && pr != nil {
+		roleGap, err = err.Error(), nil
+	}
 	if err != nil {
-		h.handleError(w, err)
+		h.handleError(w, r, err)
 		return
 	}
 
 	response := map[string]interface{}{
 		"pr": h.convertPRToResponse(pr),
 	}
+	if roleGap != "" {
+		response["role_gap"] = roleGap
+	}
+	addExplanationToResponse(response, pr)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 
 	err = json.NewEncoder(w).Encode(response)
 	if err != nil {
-		log.Printf("response encode error: %v", err)
+		slog.ErrorContext(r.Context(), "response encode error", "error", err)
 	}
 }
 
+const (
+	defaultSimulationRuns = 100
+	defaultSimulationSeed = 1
+)
+
+func (h *Handler) PRSimulate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		return
+	}
+
+	var req struct {
+		AuthorID       string   `json:"author_id"`
+		Strategy       string   `json:"strategy"`
+		WithinHours    int      `json:"within_hours"`
+		PairWindowDays int      `json:"pair_window_days"`
+		Paths          []string `json:"paths"`
+		ExtraTeams     []string `json:"extra_teams"`
+		Runs           *int     `json:"runs"`
+		Seed           *int64   `json:"seed"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	opts := domain.AssignmentOptions{
+		Strategy:       domain.AssignmentStrategy(req.Strategy),
+		WithinHours:    req.WithinHours,
+		PairWindowDays: req.PairWindowDays,
+		Paths:          req.Paths,
+		ExtraTeams:     req.ExtraTeams,
+	}
+	runs := defaultSimulationRuns
+	if req.Runs != nil {
+		runs = *req.Runs
+	}
+	seed := int64(defaultSimulationSeed)
+	if req.Seed != nil {
+		seed = *req.Seed
+	}
+
+	simulation, err := h.service.PRSimulate(r.Context(), req.AuthorID, opts, runs, seed)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	distribution := make([]map[string]interface{}, 0, len(simulation.Counts))
+	for _, userID := range sortedByCount(simulation.Counts) {
+		count := simulation.Counts[userID]
+		distribution = append(distribution, map[string]interface{}{
+			"user_id": userID,
+			"count":   count,
+			"share":   float64(count) / float64(simulation.Runs),
+		})
+	}
+
+	response := map[string]interface{}{
+		"reviewers":    simulation.Reviewers,
+		"runs":         simulation.Runs,
+		"seed":         seed,
+		"distribution": distribution,
+	}
+	if simulation.Explanation != nil {
+		response["assignment_explanation"] = convertExplanationToResponse(simulation.Explanation)
+	}
+	if simulation.RoleGap != "" {
+		response["role_gap"] = simulation.RoleGap
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "response encode error", "error", err)
+	}
+}
+
+// sortedByCount orders user ids by descending count, then by id.
+func sortedByCount(counts map[string]int) []string {
+	userIDs := make([]string, 0, len(counts))
+	for userID := range counts {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Slice(userIDs, func(i, j int) bool {
+		if counts[userIDs[i]] != counts[userIDs[j]] {
+			return counts[userIDs[i]] > counts[userIDs[j]]
+		}
+		return userIDs[i] < userIDs[j]
+	})
+	return userIDs
+}
+
 func (h *Handler) PRMerge(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		h.writeError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
 		return
 	}
 
@@ -56,13 +174,13 @@ func (h *Handler) PRMerge(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
 		return
 	}
 
 	pr, err := h.service.PRMerge(r.Context(), req.PullRequestID)
 	if err != nil {
-		h.handleError(w, err)
+		h.handleError(w, r, err)
 		return
 	}
 
@@ -73,29 +191,30 @@ func (h *Handler) PRMerge(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(response)
 	if err != nil {
-		log.Printf("response encode error: %v", err)
+		slog.ErrorContext(r.Context(), "response encode error", "error", err)
 	}
 }
 
 func (h *Handler) PRReassign(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		h.writeError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
 		return
 	}
 
 	var req struct {
 		PullRequestID string `json:"pull_request_id"`
 		OldUserID     string `json:"old_reviewer_id"`
+		NewUserID     string `json:"new_reviewer_id"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
 		return
 	}
 
-	pr, replacedBy, err := h.service.PRreassign(r.Context(), req.PullRequestID, req.OldUserID)
+	pr, replacedBy, err := h.service.PRreassign(r.Context(), req.PullRequestID, req.OldUserID, req.NewUserID)
 	if err != nil {
-		h.handleError(w, err)
+		h.handleError(w, r, err)
 		return
 	}
 
@@ -103,11 +222,274 @@ func (h *Handler) PRReassign(w http.ResponseWriter, r *http.Request) {
 		"pr":          h.convertPRToResponse(pr),
 		"replaced_by": replacedBy,
 	}
+	addExplanationToResponse(response, pr)
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "response encode error", "error", err)
+	}
+}
+
+func (h *Handler) PRDecline(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		return
+	}
+
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		ReviewerID    string `json:"reviewer_id"`
+		Reason        string `json:"reason"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	pr, replacedBy, err := h.service.PRDecline(r.Context(), req.PullRequestID, req.ReviewerID, req.Reason)
+	roleGap := ""
+	if errors.Is(err, domain.ErrRoleRequirement) && pr != nil {
+		roleGap, err = err.Error(), nil
+	}
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"pr": h.convertPRToResponse(pr),
+	}
+	if replacedBy != "" {
+		response["replaced_by"] = replacedBy
+	}
+	if roleGap != "" {
+		response["role_gap"] = roleGap
+	}
+	addExplanationToResponse(response, pr)
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "response encode error", "error", err)
+	}
+}
+
+func (h *Handler) PRFill(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		return
+	}
+
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	pr, added, err := h.service.PRFill(r.Context(), req.PullRequestID)
+	roleGap := ""
+	if errors.Is(err, domain.ErrRoleRequirement) && pr != nil {
+		roleGap, err = err.Error(), nil
+	}
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	if added == nil {
+		added = []string{}
+	}
+
+	response := map[string]interface{}{
+		"pr":              h.convertPRToResponse(pr),
+		"added_reviewers": added,
+	}
+	if roleGap != "" {
+		response["role_gap"] = roleGap
+	}
+	addExplanationToResponse(response, pr)
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "response encode error", "error", err)
+	}
+}
+
+func (h *Handler) PRReview(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		return
+	}
+
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		ReviewerID    string `json:"reviewer_id"`
+		Decision      string `json:"decision"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	pr, err := h.service.PRReview(r.Context(), req.PullRequestID, req.ReviewerID, domain.ReviewerAction(req.Decision))
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"pr": h.convertPRToResponse(pr),
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(response)
 	if err != nil {
-		log.Printf("response encode error: %v", err)
+		slog.ErrorContext(r.Context(), "response encode error", "error", err)
+	}
+}
+
+func (h *Handler) PRAddReviewer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		return
+	}
+
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		ReviewerID    string `json:"reviewer_id"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	pr, err := h.service.PRAddReviewer(r.Context(), req.PullRequestID, req.ReviewerID)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"pr": h.convertPRToResponse(pr),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "response encode error", "error", err)
+	}
+}
+
+func (h *Handler) PRRemoveReviewer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		return
+	}
+
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		ReviewerID    string `json:"reviewer_id"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	pr, err := h.service.PRRemoveReviewer(r.Context(), req.PullRequestID, req.ReviewerID)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"pr": h.convertPRToResponse(pr),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "response encode error", "error", err)
+	}
+}
+
+const defaultPRListLimit = 100
+
+func (h *Handler) PRList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		return
+	}
+
+	f, ok := responseFormat(r)
+	if !ok {
+		h.writeFormatError(w, r)
+		return
+	}
+	query := r.URL.Query()
+	period, err := service.ParseTimeRange(query.Get("from"), query.Get("to"), query.Get("window"), time.Now())
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	filter := domain.PRFilter{
+		AuthorID:   query.Get("author_id"),
+		TeamName:   query.Get("team_name"),
+		ReviewerID: query.Get("reviewer_id"),
+		Period:     period,
+	}
+	switch query.Get("status") {
+	case "":
+	case "OPEN":
+		status := domain.Open
+		filter.Status = &status
+	case "MERGED":
+		status := domain.Merged
+		filter.Status = &status
+	default:
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_FILTER", "status must be OPEN or MERGED")
+		return
+	}
+	filter.Limit, err = intParam(query.Get("limit"), defaultPRListLimit)
+	if err != nil {
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_FILTER", "limit must be an integer")
+		return
+	}
+	filter.Offset, err = intParam(query.Get("offset"), 0)
+	if err != nil {
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_FILTER", "offset must be an integer")
+		return
+	}
+
+	prs, err := h.service.PRList(r.Context(), filter)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	if f != formatJSON {
+		h.writeTable(w, r, f, prTable(prs))
+		return
+	}
+
+	result := make([]map[string]interface{}, len(prs))
+	for i, pr := range prs {
+		result[i] = h.convertPRToResponse(pr)
+	}
+	response := map[string]interface{}{
+		"pull_requests": result,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "response encode error", "error", err)
 	}
 }
 
@@ -125,13 +507,44 @@ func (h *Handler) convertPRToResponse(pr *domain.PullRequest) map[string]interfa
 		"assigned_reviewers": pr.ReviewersID,
 	}
 
+	if !pr.CreatedAt.IsZero() {
+		response["createdAt"] = pr.CreatedAt.Format(time.RFC3339)
+	}
 	if pr.Status == domain.Merged && pr.MergedAt != nil {
 		response["mergedAt"] = pr.MergedAt.Format(time.RFC3339)
 	}
+	if len(pr.ReviewerTeams) > 0 {
+		response["reviewer_teams"] = pr.ReviewerTeams
+	}
+	if len(pr.ExtraTeams) > 0 {
+		response["extra_teams"] = pr.ExtraTeams
+	}
 
 	return response
 }
 
+func addExplanationToResponse(response map[string]interface{}, pr *domain.PullRequest) {
+	if pr.Explanation == nil {
+		return
+	}
+	response["assignment_explanation"] = convertExplanationToResponse(pr.Explanation)
+}
+
+func convertExplanationToResponse(explanation *domain.AssignmentExplanation) map[string]interface{} {
+	excluded := make([]map[string]interface{}, len(explanation.Excluded))
+	for i, exclusion := range explanation.Excluded {
+		excluded[i] = map[string]interface{}{
+			"user_id": exclusion.UserID,
+			"reason":  exclusion.Reason,
+		}
+	}
+	return map[string]interface{}{
+		"strategy":   explanation.Strategy,
+		"candidates": explanation.Candidates,
+		"excluded":   excluded,
+	}
+}
+
 func (h *Handler) convertPRsToShortResponse(prs []*domain.PullRequest) []map[string]interface{} {
 	result := make([]map[string]interface{}, len(prs))
 	for i, pr := range prs {
@@ -2,25 +2,175 @@ package handler
 
 import (
 	"encoding/json"
-	"log"
+	"log/slog"
 	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/J0hnLenin/ReviewRequest/domain"
+	"github.com/J0hnLenin/ReviewRequest/service"
 )
 
 func (h *Handler) GetStatistics(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		h.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		h.writeError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		return
+	}
+
+	f, ok := responseFormat(r)
+	if !ok {
+		h.writeFormatError(w, r)
+		return
+	}
+	query := r.URL.Query()
+	period, err := service.ParseTimeRange(query.Get("from"), query.Get("to"), query.Get("window"), time.Now())
+	if err != nil {
+		h.handleError(w, r, err)
 		return
 	}
 
-	stats, err := h.service.GetStatistics(r.Context())
+	stats, err := h.service.GetStatistics(r.Context(), period)
 	if err != nil {
-		h.handleError(w, err)
+		h.handleError(w, r, err)
+		return
+	}
+	if f != formatJSON {
+		h.writeTable(w, r, f, statisticsTable(stats))
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(stats)
 	if err != nil {
-		log.Printf("response encode error: %v", err)
+		slog.ErrorContext(r.Context(), "response encode error", "error", err)
+	}
+}
+
+func (h *Handler) GetTeamStatistics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		return
+	}
+
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		h.writeError(w, r, http.StatusBadRequest, "MISSING_PARAM", "team_name is required")
+		return
+	}
+
+	stats, err := h.service.GetTeamStatistics(r.Context(), teamName)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(stats)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "response encode error", "error", err)
+	}
+}
+
+func (h *Handler) GetLatencyStatistics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	period, err := service.ParseTimeRange(query.Get("from"), query.Get("to"), query.Get("window"), time.Now())
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	stats, err := h.service.GetLatencyStatistics(r.Context(), period)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(stats)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "response encode error", "error", err)
+	}
+}
+
+const defaultLeaderboardLimit = 50
+
+func (h *Handler) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	period, err := service.ParseTimeRange(query.Get("from"), query.Get("to"), query.Get("window"), time.Now())
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	limit, err := intParam(query.Get("limit"), defaultLeaderboardLimit)
+	if err != nil {
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_LEADERBOARD", "limit must be an integer")
+		return
+	}
+	offset, err := intParam(query.Get("offset"), 0)
+	if err != nil {
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_LEADERBOARD", "offset must be an integer")
+		return
+	}
+
+	metric := domain.LeaderboardMetric(query.Get("metric"))
+	leaderboard, err := h.service.GetLeaderboard(r.Context(), metric, period, limit, offset)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(leaderboard)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "response encode error", "error", err)
+	}
+}
+
+func (h *Handler) GetTimeSeries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	period, err := service.ParseTimeRange(query.Get("from"), query.Get("to"), query.Get("window"), time.Now())
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	bucket := domain.TimeBucket(query.Get("bucket"))
+	if bucket == "" {
+		bucket = domain.BucketDay
+	}
+	metric := domain.TimeSeriesMetric(query.Get("metric"))
+	series, err := h.service.GetTimeSeries(r.Context(), metric, bucket, query.Get("team_name"), period)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(series)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "response encode error", "error", err)
+	}
+}
+
+// intParam parses an optional integer query parameter.
+func intParam(value string, def int) (int, error) {
+	if value == "" {
+		return def, nil
 	}
+	return strconv.Atoi(value)
 }
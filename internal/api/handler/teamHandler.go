@@ -2,94 +2,265 @@ package handler
 
 import (
 	"encoding/json"
-	"log"
+	"log/slog"
+	"math"
 	"net/http"
 
 	"github.com/J0hnLenin/ReviewRequest/domain"
+	"github.com/J0hnLenin/ReviewRequest/service"
 )
 
+const maxCodeownersSize = 1 << 20
+
 func (h *Handler) TeamAdd(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		h.writeError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
 		return
 	}
 
 	var req struct {
-		TeamName string                   `json:"team_name"`
-		Members  []map[string]interface{} `json:"members"`
+		TeamName         string                   `json:"team_name"`
+		Members          []map[string]interface{} `json:"members"`
+		RoleRequirements []roleRequirementRequest `json:"role_requirements"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
 		return
 	}
 
 	team := &domain.Team{
-		Name:    req.TeamName,
-		Members: make([]*domain.User, len(req.Members)),
+		Name:             req.TeamName,
+		Members:          make([]*domain.User, len(req.Members)),
+		RoleRequirements: convertRoleRequirements(req.RoleRequirements),
 	}
 
 	for i, member := range req.Members {
 		userID, _ := member["user_id"].(string)
 		username, _ := member["username"].(string)
 		isActive, _ := member["is_active"].(bool)
+		role, _ := member["role"].(string)
+		workingHours, err := parseMemberWorkingHours(member)
+		if err != nil {
+			h.writeError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "invalid working hours")
+			return
+		}
+		tags, ok := parseMemberTags(member)
+		if !ok {
+			h.writeError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "tags must be a list of strings")
+			return
+		}
+		reviewWeight, ok := parseMemberReviewWeight(member)
+		if !ok {
+			h.writeError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "review_weight must be an integer")
+			return
+		}
 
 		team.Members[i] = &domain.User{
-			ID:       userID,
-			Name:     username,
-			TeamName: req.TeamName,
-			IsActive: isActive,
+			ID:           userID,
+			Name:         username,
+			TeamName:     req.TeamName,
+			IsActive:     isActive,
+			WorkingHours: workingHours,
+			Tags:         tags,
+			Role:         domain.Role(role),
+			ReviewWeight: reviewWeight,
 		}
 	}
 
 	if err := h.service.TeamSave(r.Context(), team); err != nil {
-		h.handleError(w, err)
+		h.handleError(w, r, err)
 		return
 	}
 
 	response := map[string]interface{}{
-		"team": map[string]interface{}{
-			"team_name": team.Name,
-			"members":   h.convertMembersToResponse(team.Members),
-		},
+		"team": h.convertTeamToResponse(team),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	err := json.NewEncoder(w).Encode(response)
 	if err != nil {
-		log.Printf("response encode error: %v", err)
+		slog.ErrorContext(r.Context(), "response encode error", "error", err)
 	}
 }
 
 func (h *Handler) TeamGet(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		h.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		h.writeError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
 		return
 	}
 
 	teamName := r.URL.Query().Get("team_name")
 	if teamName == "" {
-		h.writeError(w, http.StatusBadRequest, "MISSING_PARAM", "team_name is required")
+		h.writeError(w, r, http.StatusBadRequest, "MISSING_PARAM", "team_name is required")
 		return
 	}
 
 	team, err := h.service.TeamGetByName(r.Context(), teamName)
 	if err != nil {
-		h.handleError(w, err)
+		h.handleError(w, r, err)
 		return
 	}
 
-	response := map[string]interface{}{
-		"team_name": team.Name,
-		"members":   h.convertMembersToResponse(team.Members),
+	response := h.convertTeamToResponse(team)
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "response encode error", "error", err)
+	}
+}
+
+func (h *Handler) TeamSetCodeowners(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		return
+	}
+
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		h.writeError(w, r, http.StatusBadRequest, "MISSING_PARAM", "team_name is required")
+		return
+	}
+
+	rules, err := service.ParseCodeowners(http.MaxBytesReader(w, r.Body, maxCodeownersSize))
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	team, err := h.service.TeamSetOwnerRules(r.Context(), teamName, rules)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	response := h.convertTeamToResponse(team)
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "response encode error", "error", err)
+	}
+}
+
+func (h *Handler) TeamSetRoleRequirements(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		return
+	}
+
+	var req struct {
+		TeamName         string                   `json:"team_name"`
+		RoleRequirements []roleRequirementRequest `json:"role_requirements"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	team, err := h.service.TeamSetRoleRequirements(r.Context(), req.TeamName, convertRoleRequirements(req.RoleRequirements))
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	response := h.convertTeamToResponse(team)
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "response encode error", "error", err)
+	}
+}
+
+func (h *Handler) TeamSetSLA(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		return
 	}
 
+	var req struct {
+		TeamName string `json:"team_name"`
+		SLAHours int    `json:"sla_hours"`
+		Action   string `json:"action"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	policy := domain.SLAPolicy{
+		Hours:  req.SLAHours,
+		Action: domain.SLAAction(req.Action),
+	}
+	team, err := h.service.TeamSetSLA(r.Context(), req.TeamName, policy)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	response := h.convertTeamToResponse(team)
+
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(response)
 	if err != nil {
-		log.Printf("response encode error: %v", err)
+		slog.ErrorContext(r.Context(), "response encode error", "error", err)
+	}
+}
+
+type roleRequirementRequest struct {
+	Role  string `json:"role"`
+	Count int    `json:"count"`
+}
+
+func convertRoleRequirements(requirements []roleRequirementRequest) []domain.RoleRequirement {
+	result := make([]domain.RoleRequirement, len(requirements))
+	for i, req := range requirements {
+		result[i] = domain.RoleRequirement{
+			MinRole: domain.Role(req.Role),
+			Count:   req.Count,
+		}
+	}
+	return result
+}
+
+func (h *Handler) convertTeamToResponse(team *domain.Team) map[string]interface{} {
+	response := map[string]interface{}{
+		"team_name": team.Name,
+		"members":   h.convertMembersToResponse(team.Members),
+	}
+	if len(team.OwnerRules) > 0 {
+		rules := make([]map[string]interface{}, len(team.OwnerRules))
+		for i, rule := range team.OwnerRules {
+			rules[i] = map[string]interface{}{
+				"pattern": rule.Pattern,
+				"users":   nonNilStrings(rule.UserIDs),
+				"tags":    nonNilStrings(rule.Tags),
+			}
+		}
+		response["owner_rules"] = rules
+	}
+	if len(team.RoleRequirements) > 0 {
+		requirements := make([]map[string]interface{}, len(team.RoleRequirements))
+		for i, req := range team.RoleRequirements {
+			requirements[i] = map[string]interface{}{
+				"role":  req.MinRole,
+				"count": req.Count,
+			}
+		}
+		response["role_requirements"] = requirements
+	}
+	if team.SLA.Hours > 0 {
+		response["sla"] = map[string]interface{}{
+			"sla_hours": team.SLA.Hours,
+			"action":    team.SLA.Action,
+		}
 	}
+	return response
 }
 
 func (h *Handler) convertMembersToResponse(members []*domain.User) []map[string]interface{} {
@@ -100,13 +271,70 @@ func (h *Handler) convertMembersToResponse(members []*domain.User) []map[string]
 			"username":  member.Name,
 			"is_active": member.IsActive,
 		}
+		addWorkingHoursToResponse(result[i], member.WorkingHours)
+		if len(member.Tags) > 0 {
+			result[i]["tags"] = member.Tags
+		}
+		if member.Role != "" {
+			result[i]["role"] = member.Role
+		}
+		if member.ReviewWeight != nil {
+			result[i]["review_weight"] = *member.ReviewWeight
+		}
 	}
 	return result
 }
 
+func parseMemberWorkingHours(member map[string]interface{}) (domain.WorkingHours, error) {
+	timezone, _ := member["timezone"].(string)
+	workStart, _ := member["work_start"].(string)
+	workEnd, _ := member["work_end"].(string)
+	return parseWorkingHours(timezone, workStart, workEnd)
+}
+
+func parseMemberReviewWeight(member map[string]interface{}) (*int, bool) {
+	raw, present := member["review_weight"]
+	if !present || raw == nil {
+		return nil, true
+	}
+	value, ok := raw.(float64)
+	if !ok || value != math.Trunc(value) {
+		return nil, false
+	}
+	weight := int(value)
+	return &weight, true
+}
+
+func parseMemberTags(member map[string]interface{}) ([]string, bool) {
+	raw, present := member["tags"]
+	if !present || raw == nil {
+		return nil, true
+	}
+	values, ok := raw.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	tags := make([]string, len(values))
+	for i, value := range values {
+		tag, ok := value.(string)
+		if !ok {
+			return nil, false
+		}
+		tags[i] = tag
+	}
+	return tags, true
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
 func (h *Handler) TeamSetIsActive(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		h.writeError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
 		return
 	}
 
@@ -116,24 +344,21 @@ func (h *Handler) TeamSetIsActive(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
 		return
 	}
 
 	team, err := h.service.TeamChangeActive(r.Context(), req.TeamNameID, req.IsActive)
 	if err != nil {
-		h.handleError(w, err)
+		h.handleError(w, r, err)
 		return
 	}
 
-	response := map[string]interface{}{
-		"team_name": team.Name,
-		"members":   h.convertMembersToResponse(team.Members),
-	}
+	response := h.convertTeamToResponse(team)
 
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(response)
 	if err != nil {
-		log.Printf("response encode error: %v", err)
+		slog.ErrorContext(r.Context(), "response encode error", "error", err)
 	}
 }
@@ -2,13 +2,19 @@ package handler
 
 import (
 	"encoding/json"
-	"log"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"time"
+
+	"github.com/J0hnLenin/ReviewRequest/domain"
+	"github.com/J0hnLenin/ReviewRequest/service"
 )
 
 func (h *Handler) UserSetIsActive(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		h.writeError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
 		return
 	}
 
@@ -18,47 +24,51 @@ func (h *Handler) UserSetIsActive(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
 		return
 	}
 
 	user, err := h.service.UserChangeActive(r.Context(), req.UserID, req.IsActive)
 	if err != nil {
-		h.handleError(w, err)
+		h.handleError(w, r, err)
 		return
 	}
 
 	response := map[string]interface{}{
-		"user": map[string]interface{}{
-			"user_id":   user.ID,
-			"username":  user.Name,
-			"team_name": user.TeamName,
-			"is_active": user.IsActive,
-		},
+		"user": h.convertUserToResponse(user),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(response)
 	if err != nil {
-		log.Printf("response encode error: %v", err)
+		slog.ErrorContext(r.Context(), "response encode error", "error", err)
 	}
 }
 
 func (h *Handler) UserGetReviews(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		h.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		h.writeError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
 		return
 	}
 
+	f, ok := responseFormat(r)
+	if !ok {
+		h.writeFormatError(w, r)
+		return
+	}
 	userID := r.URL.Query().Get("user_id")
 	if userID == "" {
-		h.writeError(w, http.StatusBadRequest, "MISSING_PARAM", "user_id is required")
+		h.writeError(w, r, http.StatusBadRequest, "MISSING_PARAM", "user_id is required")
 		return
 	}
 
 	prs, err := h.service.UserGetReviews(r.Context(), userID)
 	if err != nil {
-		h.handleError(w, err)
+		h.handleError(w, r, err)
+		return
+	}
+	if f != formatJSON {
+		h.writeTable(w, r, f, prTable(prs))
 		return
 	}
 
@@ -70,6 +80,170 @@ func (h *Handler) UserGetReviews(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(response)
 	if err != nil {
-		log.Printf("response encode error: %v", err)
+		slog.ErrorContext(r.Context(), "response encode error", "error", err)
+	}
+}
+
+func (h *Handler) UserGetStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	userID := query.Get("user_id")
+	if userID == "" {
+		h.writeError(w, r, http.StatusBadRequest, "MISSING_PARAM", "user_id is required")
+		return
+	}
+	period, err := service.ParseTimeRange(query.Get("from"), query.Get("to"), query.Get("window"), time.Now())
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	profile, err := h.service.GetUserProfile(r.Context(), userID, period)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(profile)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "response encode error", "error", err)
+	}
+}
+
+func (h *Handler) UserSetWorkingHours(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		return
+	}
+
+	var req struct {
+		UserID    string `json:"user_id"`
+		TimeZone  string `json:"timezone"`
+		WorkStart string `json:"work_start"`
+		WorkEnd   string `json:"work_end"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	workingHours, err := parseWorkingHours(req.TimeZone, req.WorkStart, req.WorkEnd)
+	if err != nil {
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "invalid working hours")
+		return
+	}
+
+	user, err := h.service.UserSetWorkingHours(r.Context(), req.UserID, workingHours)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"user": h.convertUserToResponse(user),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "response encode error", "error", err)
+	}
+}
+
+func (h *Handler) UserSetReviewWeight(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		return
+	}
+
+	var req struct {
+		UserID       string `json:"user_id"`
+		ReviewWeight *int   `json:"review_weight"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ReviewWeight == nil {
+		h.writeError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	user, err := h.service.UserSetReviewWeight(r.Context(), req.UserID, *req.ReviewWeight)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"user": h.convertUserToResponse(user),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "response encode error", "error", err)
+	}
+}
+
+func (h *Handler) convertUserToResponse(user *domain.User) map[string]interface{} {
+	response := map[string]interface{}{
+		"user_id":   user.ID,
+		"username":  user.Name,
+		"team_name": user.TeamName,
+		"is_active": user.IsActive,
+	}
+	addWorkingHoursToResponse(response, user.WorkingHours)
+	if len(user.Tags) > 0 {
+		response["tags"] = user.Tags
+	}
+	if user.Role != "" {
+		response["role"] = user.Role
 	}
+	if user.ReviewWeight != nil {
+		response["review_weight"] = *user.ReviewWeight
+	}
+	return response
+}
+
+func addWorkingHoursToResponse(response map[string]interface{}, wh domain.WorkingHours) {
+	if wh.TimeZone == "" {
+		return
+	}
+	response["timezone"] = wh.TimeZone
+	response["work_start"] = formatClock(wh.Start)
+	response["work_end"] = formatClock(wh.End)
+}
+
+func parseWorkingHours(timezone, workStart, workEnd string) (domain.WorkingHours, error) {
+	if timezone == "" {
+		return domain.WorkingHours{}, nil
+	}
+	start, err := parseClock(workStart)
+	if err != nil {
+		return domain.WorkingHours{}, err
+	}
+	end, err := parseClock(workEnd)
+	if err != nil {
+		return domain.WorkingHours{}, err
+	}
+	return domain.WorkingHours{TimeZone: timezone, Start: start, End: end}, nil
+}
+
+func parseClock(value string) (int, error) {
+	var hours, minutes int
+	if _, err := fmt.Sscanf(value, "%d:%d", &hours, &minutes); err != nil {
+		return 0, err
+	}
+	if hours < 0 || hours > 23 || minutes < 0 || minutes > 59 {
+		return 0, errors.New("clock value out of range")
+	}
+	return hours*60 + minutes, nil
+}
+
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
 }
@@ -0,0 +1,80 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJSONLogger_AddsRequestID(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	logger := NewJSONLogger(&buf, slog.LevelInfo).With("component", "repo")
+	ctx := WithRequestID(context.Background(), "req-1")
+
+	// Act
+	logger.ErrorContext(ctx, "query failed", "error", "connection reset")
+	logger.InfoContext(context.Background(), "no request")
+
+	// Assert
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+
+	var record map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &record))
+	assert.Equal(t, "req-1", record["request_id"])
+	assert.Equal(t, "repo", record["component"])
+	assert.Equal(t, "connection reset", record["error"])
+
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &record))
+	assert.NotContains(t, lines[1], "request_id")
+}
+
+func TestMiddleware_PropagatesRequestID(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		keep   bool
+	}{
+		{name: "client ID", header: "abc-123", keep: true},
+		{name: "missing", header: ""},
+		{name: "control characters", header: "abc\tdef"},
+		{name: "too long", header: strings.Repeat("a", maxRequestIDLength+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var seen string
+			handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				seen = RequestID(r.Context())
+				w.WriteHeader(http.StatusTeapot)
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/team/get", nil)
+			if tt.header != "" {
+				req.Header.Set(RequestIDHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(rec, req)
+
+			// Assert
+			assert.Equal(t, http.StatusTeapot, rec.Code)
+			assert.NotEmpty(t, seen)
+			assert.Equal(t, seen, rec.Header().Get(RequestIDHeader))
+			if tt.keep {
+				assert.Equal(t, tt.header, seen)
+			} else {
+				assert.Len(t, seen, 32)
+			}
+		})
+	}
+}
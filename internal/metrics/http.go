@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// unmatchedRoute labels requests that match no registered pattern, so
+// arbitrary paths do not create new series.
+const unmatchedRoute = "unmatched"
+
+// HTTP counts requests and their latencies per route of a ServeMux.
+type HTTP struct {
+	requests *CounterVec
+	duration *HistogramVec
+}
+
+func NewHTTP(reg *Registry) *HTTP {
+	return &HTTP{
+		requests: reg.Counter("http_requests_total",
+			"HTTP requests by route, method and status code.", "route", "method", "code"),
+		duration: reg.Histogram("http_request_duration_seconds",
+			"HTTP request latency by route.", DefaultBuckets, "route", "method"),
+	}
+}
+
+// Wrap serves requests with mux, labelling them with the matched pattern.
+func (m *HTTP) Wrap(mux *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := unmatchedRoute
+		if _, pattern := mux.Handler(r); pattern != "" {
+			route = pattern
+		}
+
+		start := time.Now()
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		mux.ServeHTTP(sw, r)
+
+		m.requests.Inc(route, r.Method, strconv.Itoa(sw.status))
+		m.duration.Observe(time.Since(start).Seconds(), route, r.Method)
+	})
+}
+
+type statusWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
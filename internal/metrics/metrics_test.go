@@ -0,0 +1,144 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func scrape(t *testing.T, reg *Registry) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	reg.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+	return rec.Body.String()
+}
+
+func TestHandler_WritesCountersAndGauges(t *testing.T) {
+	// Arrange
+	reg := NewRegistry()
+	requests := reg.Counter("requests_total", "Requests.", "code")
+	open := reg.Gauge("open_prs", "Open PRs.")
+
+	requests.Inc("500")
+	requests.Add(2, "200")
+	open.Set(7)
+
+	// Act
+	body := scrape(t, reg)
+
+	// Assert
+	assert.Equal(t, `# HELP requests_total Requests.
+# TYPE requests_total counter
+requests_total{code="200"} 2
+requests_total{code="500"} 1
+# HELP open_prs Open PRs.
+# TYPE open_prs gauge
+open_prs 7
+`, body)
+}
+
+func TestHandler_WritesCumulativeHistogram(t *testing.T) {
+	// Arrange
+	reg := NewRegistry()
+	latency := reg.Histogram("latency_seconds", "Latency.", []float64{0.1, 1}, "op")
+
+	latency.Observe(0.05, "get")
+	latency.Observe(0.1, "get")
+	latency.Observe(0.5, "get")
+	latency.Observe(3, "get")
+
+	// Act
+	body := scrape(t, reg)
+
+	// Assert
+	assert.Equal(t, `# HELP latency_seconds Latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{op="get",le="0.1"} 2
+latency_seconds_bucket{op="get",le="1"} 3
+latency_seconds_bucket{op="get",le="+Inf"} 4
+latency_seconds_sum{op="get"} 3.65
+latency_seconds_count{op="get"} 4
+`, body)
+}
+
+func TestHandler_EscapesLabelValues(t *testing.T) {
+	// Arrange
+	reg := NewRegistry()
+	reg.Gauge("team_load", "Load.", "team").Set(1, "a\"b\\c\nd")
+
+	// Act
+	body := scrape(t, reg)
+
+	// Assert
+	assert.Contains(t, body, `team_load{team="a\"b\\c\nd"} 1`)
+}
+
+func TestHandler_RunsCollectorsBeforeWriting(t *testing.T) {
+	// Arrange
+	reg := NewRegistry()
+	load := reg.Gauge("team_load", "Load.", "team")
+	load.Set(5, "removed")
+	reg.OnScrape(func(ctx context.Context) error {
+		load.Reset()
+		load.Set(3, "backend")
+		return nil
+	})
+	reg.OnScrape(func(ctx context.Context) error {
+		return errors.New("database down")
+	})
+
+	// Act
+	body := scrape(t, reg)
+
+	// Assert
+	assert.Contains(t, body, `team_load{team="backend"} 3`)
+	assert.NotContains(t, body, "removed")
+}
+
+func TestNilVecsIgnoreUpdates(t *testing.T) {
+	var counter *CounterVec
+	var gauge *GaugeVec
+	var histogram *HistogramVec
+
+	assert.NotPanics(t, func() {
+		counter.Inc("x")
+		gauge.Set(1, "x")
+		gauge.Reset()
+		histogram.Observe(1, "x")
+	})
+}
+
+func TestHTTP_LabelsRequestsByRoute(t *testing.T) {
+	// Arrange
+	reg := NewRegistry()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/team/get", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	})
+	server := httptest.NewServer(NewHTTP(reg).Wrap(mux))
+	defer server.Close()
+
+	// Act
+	for _, path := range []string{"/team/get?team_name=a", "/team/get?team_name=b", "/health", "/nope"} {
+		resp, err := http.Get(server.URL + path)
+		assert.NoError(t, err)
+		resp.Body.Close()
+	}
+	body := scrape(t, reg)
+
+	// Assert
+	assert.Contains(t, body, `http_requests_total{route="/team/get",method="GET",code="404"} 2`)
+	assert.Contains(t, body, `http_requests_total{route="/health",method="GET",code="200"} 1`)
+	assert.Contains(t, body, `http_requests_total{route="unmatched",method="GET",code="404"} 1`)
+	assert.Contains(t, body, `http_request_duration_seconds_count{route="/team/get",method="GET"} 2`)
+}
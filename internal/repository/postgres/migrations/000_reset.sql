@@ -1,5 +1,6 @@
 SET session_replication_role = 'replica';
 
+DROP TABLE IF EXISTS reviewer_events CASCADE;
 DROP TABLE IF EXISTS pr_reviewers CASCADE;
 DROP TABLE IF EXISTS pull_requests CASCADE;
 DROP TABLE IF EXISTS teams CASCADE;
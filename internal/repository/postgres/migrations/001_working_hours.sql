@@ -0,0 +1,4 @@
+ALTER TABLE users
+    ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN work_start SMALLINT NOT NULL DEFAULT 0,
+    ADD COLUMN work_end SMALLINT NOT NULL DEFAULT 0;
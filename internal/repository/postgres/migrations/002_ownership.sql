@@ -0,0 +1,5 @@
+ALTER TABLE users
+    ADD COLUMN tags VARCHAR(255)[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE teams
+    ADD COLUMN owner_rules JSONB NOT NULL DEFAULT '[]';
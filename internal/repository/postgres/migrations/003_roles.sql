@@ -0,0 +1,5 @@
+ALTER TABLE users
+    ADD COLUMN role VARCHAR(16) NOT NULL DEFAULT '';
+
+ALTER TABLE teams
+    ADD COLUMN role_requirements JSONB NOT NULL DEFAULT '[]';
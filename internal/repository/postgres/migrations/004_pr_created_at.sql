@@ -0,0 +1,4 @@
+ALTER TABLE pull_requests
+    ADD COLUMN created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now();
+
+CREATE INDEX idx_pull_requests_author_created_at ON pull_requests(author_id, created_at);
@@ -0,0 +1,11 @@
+CREATE TABLE reviewer_events (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(id),
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id),
+    action VARCHAR(32) NOT NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_reviewer_events_pull_request_id ON reviewer_events(pull_request_id);
+CREATE INDEX idx_reviewer_events_user_id ON reviewer_events(user_id);
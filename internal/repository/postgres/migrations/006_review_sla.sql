@@ -0,0 +1,5 @@
+ALTER TABLE teams
+    ADD COLUMN sla_hours INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN sla_action VARCHAR(16) NOT NULL DEFAULT 'notify';
+
+CREATE INDEX idx_pull_requests_is_merged ON pull_requests(is_merged);
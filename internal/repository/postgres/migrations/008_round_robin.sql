@@ -0,0 +1,2 @@
+ALTER TABLE teams
+    ADD COLUMN round_robin_cursor VARCHAR(255) NOT NULL DEFAULT '';
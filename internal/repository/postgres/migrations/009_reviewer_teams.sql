@@ -0,0 +1,2 @@
+ALTER TABLE pull_requests
+    ADD COLUMN reviewer_teams JSONB NOT NULL DEFAULT '{}';
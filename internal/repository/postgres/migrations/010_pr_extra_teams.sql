@@ -0,0 +1,10 @@
+ALTER TABLE pull_requests
+    ADD COLUMN extra_teams TEXT[] NOT NULL DEFAULT '{}';
+
+UPDATE pull_requests pr
+SET extra_teams = ARRAY(
+    SELECT DISTINCT rt.value
+    FROM jsonb_each_text(pr.reviewer_teams) rt
+    INNER JOIN users u ON u.id = pr.author_id
+    WHERE rt.value <> u.team_name
+);
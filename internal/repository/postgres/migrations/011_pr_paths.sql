@@ -0,0 +1,2 @@
+ALTER TABLE pull_requests
+    ADD COLUMN paths TEXT[] NOT NULL DEFAULT '{}';
@@ -1,22 +1,32 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"log/slog"
+	"time"
 
+	"github.com/J0hnLenin/ReviewRequest/internal/metrics"
 	"github.com/J0hnLenin/ReviewRequest/service"
 )
 
 type PostgresRepository struct {
 	db *sql.DB
+
+	queryDuration *metrics.HistogramVec
 }
 
 func NewPostgresRepository(connectionString string) (*PostgresRepository, error) {
 	db, err := sql.Open("postgres", connectionString)
 	if err != nil {
+		slog.Error("database open failed", "error", err)
 		return nil, service.ErrConnection
 	}
 
 	if err := db.Ping(); err != nil {
+		slog.Error("database ping failed", "error", err)
 		return nil, service.ErrConnection
 	}
 
@@ -27,5 +37,55 @@ func (r *PostgresRepository) Close() error {
 	return r.db.Close()
 }
 
+// Instrument records the latency of every repository call in reg.
+func (r *PostgresRepository) Instrument(reg *metrics.Registry) {
+	r.queryDuration = reg.Histogram("db_query_duration_seconds",
+		"Latency of Postgres repository calls by operation.", metrics.DefaultBuckets, "operation")
+}
+
+// queryError logs the underlying error, tagged with the request ID carried by
+// ctx, and hides it behind service.ErrQueryExecution.
+func queryError(ctx context.Context, operation string, err error) error {
+	if errors.Is(err, service.ErrQueryExecution) {
+		return err
+	}
+	slog.ErrorContext(ctx, "query failed", "operation", operation, "error", err)
+	return service.ErrQueryExecution
+}
 
+func (r *PostgresRepository) observe(operation string, start time.Time) {
+	r.queryDuration.Observe(time.Since(start).Seconds(), operation)
+}
 
+// TryAdvisoryLock takes a session-level advisory lock on a dedicated
+// connection, which is held until unlock is called.
+func (r *PostgresRepository) TryAdvisoryLock(ctx context.Context, key int64) (func(), bool, error) {
+	defer r.observe("TryAdvisoryLock", time.Now())
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "database connection failed", "error", err)
+		return nil, false, service.ErrConnection
+	}
+
+	var locked bool
+	err = conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, key).Scan(&locked)
+	if err != nil {
+		conn.Close()
+		return nil, false, queryError(ctx, "TryAdvisoryLock", err)
+	}
+	if !locked {
+		conn.Close()
+		return nil, false, nil
+	}
+
+	unlock := func() {
+		_, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, key)
+		if err != nil {
+			slog.ErrorContext(ctx, "advisory unlock error", "error", err)
+			// Drop the session instead of returning it to the pool with the lock held.
+			conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+		}
+		conn.Close()
+	}
+	return unlock, true, nil
+}
@@ -3,16 +3,18 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"log/slog"
 	"time"
 
 	"github.com/J0hnLenin/ReviewRequest/domain"
-	"github.com/J0hnLenin/ReviewRequest/service"
 	"github.com/lib/pq"
 )
 
 func (r *PostgresRepository) GetPRByAuthor(ctx context.Context, authorID string) ([]*domain.PullRequest, error) {
+	defer r.observe("GetPRByAuthor", time.Now())
 	query := `
-		SELECT id, title, author_id, reviewers_id, is_merged, merged_at 
+		SELECT id, title, author_id, reviewers_id, is_merged, merged_at, created_at, reviewer_teams, extra_teams
 		FROM pull_requests 
 		WHERE author_id = $1`
 
@@ -21,13 +23,13 @@ func (r *PostgresRepository) GetPRByAuthor(ctx context.Context, authorID string)
 		return nil, nil
 	}
 	if err != nil {
-		return nil, service.ErrQueryExecution
+		return nil, queryError(ctx, "GetPRByAuthor", err)
 	}
 	defer rows.Close()
 
 	var prs []*domain.PullRequest
 	for rows.Next() {
-		pr, err := r.scanPullRequest(rows)
+		pr, err := r.scanPullRequest(ctx, rows)
 		if err != nil {
 			return nil, err
 		}
@@ -38,20 +40,72 @@ func (r *PostgresRepository) GetPRByAuthor(ctx context.Context, authorID string)
 }
 
 func (r *PostgresRepository) GetPRById(ctx context.Context, id string) (*domain.PullRequest, error) {
+	defer r.observe("GetPRById", time.Now())
 	query := `
-		SELECT id, title, author_id, reviewers_id, is_merged, merged_at 
+		SELECT id, title, author_id, reviewers_id, is_merged, merged_at, created_at, reviewer_teams, extra_teams
 		FROM pull_requests 
 		WHERE id = $1`
 
 	row := r.db.QueryRowContext(ctx, query, id)
-	pr, err := r.scanPullRequest(row)
+	pr, err := r.scanPullRequest(ctx, row)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	return pr, err
 }
 
+func (r *PostgresRepository) ListPRs(ctx context.Context, filter domain.PRFilter) ([]*domain.PullRequest, error) {
+	defer r.observe("ListPRs", time.Now())
+	query := `
+		SELECT pr.id, pr.title, pr.author_id, pr.reviewers_id, pr.is_merged, pr.merged_at, pr.created_at, pr.reviewer_teams, pr.extra_teams
+		FROM pull_requests pr
+		JOIN users u ON u.id = pr.author_id
+		WHERE ` + createdIn + `
+			AND ($3::boolean IS NULL OR pr.is_merged = $3)
+			AND ($4::text = '' OR pr.author_id = $4)
+			AND ($5::text = '' OR u.team_name = $5)
+			AND ($6::text = '' OR $6 = ANY(pr.reviewers_id))
+		ORDER BY pr.created_at DESC, pr.id
+		LIMIT $7 OFFSET $8`
+
+	var merged *bool
+	if filter.Status != nil {
+		value := bool(*filter.Status)
+		merged = &value
+	}
+
+	rows, err := r.db.QueryContext(ctx, query,
+		filter.Period.From,
+		filter.Period.To,
+		merged,
+		filter.AuthorID,
+		filter.TeamName,
+		filter.ReviewerID,
+		filter.Limit,
+		filter.Offset,
+	)
+	if err != nil {
+		return nil, queryError(ctx, "ListPRs", err)
+	}
+	defer rows.Close()
+
+	var prs []*domain.PullRequest
+	for rows.Next() {
+		pr, err := r.scanPullRequest(ctx, rows)
+		if err != nil {
+			return nil, err
+		}
+		prs = append(prs, pr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, "ListPRs", err)
+	}
+
+	return prs, nil
+}
+
 func (r *PostgresRepository) GetPRAndTeam(ctx context.Context, id string) (*domain.PullRequest, *domain.Team, error) {
+	defer r.observe("GetPRAndTeam", time.Now())
 	query := `
 		SELECT 
 			pr.id,
@@ -60,10 +114,15 @@ func (r *PostgresRepository) GetPRAndTeam(ctx context.Context, id string) (*doma
 			pr.reviewers_id,
 			pr.is_merged,
 			pr.merged_at,
-			t.team_name,
-			COALESCE(array_agg(um.id ORDER BY um.id) FILTER (WHERE um.id IS NOT NULL), '{}') as member_ids,
-			COALESCE(array_agg(um.user_name ORDER BY um.id) FILTER (WHERE um.id IS NOT NULL), '{}') as member_names,
-			COALESCE(array_agg(um.is_active ORDER BY um.id) FILTER (WHERE um.id IS NOT NULL), '{}') as member_active
+			pr.created_at,
+			pr.reviewer_teams,
+			pr.extra_teams,
+			pr.paths,
+			ARRAY(
+				SELECT DISTINCT e.user_id
+				FROM reviewer_events e
+				WHERE e.pull_request_id = pr.id AND e.action = 'declined'
+			), ` + teamColumns + `
 			
 		FROM pull_requests pr
 		INNER JOIN users u ON pr.author_id = u.id
@@ -72,102 +131,281 @@ func (r *PostgresRepository) GetPRAndTeam(ctx context.Context, id string) (*doma
 		
 		WHERE pr.id = $1
 		GROUP BY 
-			pr.id, pr.title, pr.author_id, pr.reviewers_id, pr.is_merged, pr.merged_at,
+			pr.id, pr.title, pr.author_id, pr.reviewers_id, pr.is_merged, pr.merged_at, pr.created_at, pr.reviewer_teams, pr.extra_teams, pr.paths,
 			t.team_name`
 
 	var (
 		prID, prTitle, authorID string
 		reviewers               []string
+		declinedIDs             []string
 		isMerged                bool
 		mergedAt                *time.Time
+		createdAt               time.Time
+		reviewerTeams           []byte
+		extraTeams              []string
+		paths                   []string
 
-		teamName string
-		
-		memberIDs, memberNames []string
-		memberActive           []bool
+		team teamRow
 	)
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	dest := []interface{}{
 		&prID,
 		&prTitle,
 		&authorID,
 		pq.Array(&reviewers),
 		&isMerged,
 		&mergedAt,
-		
-		&teamName,
-		
-		pq.Array(&memberIDs),
-		pq.Array(&memberNames),
-		pq.Array(&memberActive),
-	)
+		&createdAt,
+		&reviewerTeams,
+		pq.Array(&extraTeams),
+		pq.Array(&paths),
+		pq.Array(&declinedIDs),
+	}
+	err := r.db.QueryRowContext(ctx, query, id).Scan(append(dest, team.dest()...)...)
 
 	if err == sql.ErrNoRows {
 		return nil, nil, nil
 	}
 	if err != nil {
-		return nil, nil, service.ErrQueryExecution
+		return nil, nil, queryError(ctx, "GetPRAndTeam", err)
+	}
+
+	teams, err := decodeReviewerTeams(reviewerTeams)
+	if err != nil {
+		return nil, nil, queryError(ctx, "GetPRAndTeam", err)
 	}
 
 	pr := &domain.PullRequest{
-		ID:          prID,
-		Title:       prTitle,
-		AuthorID:    authorID,
-		ReviewersID: reviewers,
-		Status:      domain.PRStatus(isMerged),
-		MergedAt:    mergedAt,
+		ID:            prID,
+		Title:         prTitle,
+		AuthorID:      authorID,
+		ReviewersID:   reviewers,
+		Status:        domain.PRStatus(isMerged),
+		MergedAt:      mergedAt,
+		CreatedAt:     createdAt,
+		DeclinedIDs:   declinedIDs,
+		ReviewerTeams: teams,
+		ExtraTeams:    extraTeams,
+		Paths:         paths,
 	}
 
-	team := &domain.Team{
-		Name: teamName,
+	prTeam, err := team.team()
+	if err != nil {
+		return nil, nil, queryError(ctx, "GetPRAndTeam", err)
 	}
 
-	team.Members = make([]*domain.User, len(memberIDs))
-	for i := range memberIDs {
-		team.Members[i] = &domain.User{
-			ID:       memberIDs[i],
-			Name:     memberNames[i],
-			TeamName: teamName,
-			IsActive: memberActive[i],
+	return pr, prTeam, nil
+}
+
+func (r *PostgresRepository) GetUnderReviewedPRs(ctx context.Context, teamName string, maxReviewers int, crossTeamAuthorSlots int) ([]string, error) {
+	defer r.observe("GetUnderReviewedPRs", time.Now())
+	query := `
+		SELECT pr.id
+		FROM pull_requests pr
+		INNER JOIN users u ON pr.author_id = u.id
+		WHERE NOT pr.is_merged
+			AND (
+				(u.team_name = $1 AND cardinality(pr.reviewers_id) < CASE
+					WHEN cardinality(pr.extra_teams) = 0 THEN $2
+					ELSE $3 + cardinality(pr.extra_teams)
+				END)
+				OR ($1 = ANY(pr.extra_teams) AND NOT EXISTS (
+					SELECT 1 FROM jsonb_each_text(pr.reviewer_teams) rt WHERE rt.value = $1
+				))
+			)
+		ORDER BY pr.created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, teamName, maxReviewers, crossTeamAuthorSlots)
+	if err != nil {
+		return nil, queryError(ctx, "GetUnderReviewedPRs", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, queryError(ctx, "GetUnderReviewedPRs", err)
 		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, "GetUnderReviewedPRs", err)
 	}
 
-	return pr, team, nil
+	return ids, nil
 }
 
-func (r *PostgresRepository) SavePR(ctx context.Context, pr *domain.PullRequest) error {
+func (r *PostgresRepository) GetRecentReviewCounts(ctx context.Context, authorID string, since time.Time) (map[string]int, error) {
+	defer r.observe("GetRecentReviewCounts", time.Now())
 	query := `
-		INSERT INTO pull_requests (id, title, author_id, reviewers_id, is_merged, merged_at) 
-		VALUES ($1, $2, $3, $4, $5, $6)
+		SELECT e.user_id, COUNT(DISTINCT e.pull_request_id)
+		FROM reviewer_events e
+		INNER JOIN pull_requests pr ON pr.id = e.pull_request_id
+		WHERE pr.author_id = $1 AND e.action = 'assigned' AND e.created_at >= $2
+		GROUP BY e.user_id`
+
+	rows, err := r.db.QueryContext(ctx, query, authorID, since)
+	if err != nil {
+		return nil, queryError(ctx, "GetRecentReviewCounts", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var reviewerID string
+		var count int
+		if err := rows.Scan(&reviewerID, &count); err != nil {
+			return nil, queryError(ctx, "GetRecentReviewCounts", err)
+		}
+		counts[reviewerID] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, "GetRecentReviewCounts", err)
+	}
+
+	return counts, nil
+}
+
+func (r *PostgresRepository) GetOverdueReviews(ctx context.Context, now time.Time) ([]domain.PendingReview, error) {
+	defer r.observe("GetOverdueReviews", time.Now())
+	query := `
+		SELECT
+			pr.id,
+			r.reviewer_id,
+			COALESCE(ev.assigned_at, pr.created_at)
+		FROM pull_requests pr
+		CROSS JOIN UNNEST(pr.reviewers_id) AS r(reviewer_id)
+		INNER JOIN users u ON pr.author_id = u.id
+		INNER JOIN teams t ON u.team_name = t.team_name
+		CROSS JOIN LATERAL (
+			SELECT
+				MAX(e.created_at) FILTER (WHERE e.action IN ('assigned', 'added')) AS assigned_at,
+				MAX(e.created_at) FILTER (WHERE e.action IN ('assigned', 'added', 'escalated')) AS last_touched_at,
+				MAX(e.created_at) FILTER (WHERE e.action IN ('approved', 'changes_requested')) AS decided_at
+			FROM reviewer_events e
+			WHERE e.pull_request_id = pr.id
+				AND e.user_id = r.reviewer_id
+		) ev
+		WHERE NOT pr.is_merged
+			AND t.sla_hours > 0
+			AND (ev.decided_at IS NULL OR ev.decided_at < COALESCE(ev.assigned_at, pr.created_at))
+			AND COALESCE(ev.last_touched_at, pr.created_at) <= $1 - make_interval(hours => t.sla_hours)
+		ORDER BY pr.created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, now)
+	if err != nil {
+		return nil, queryError(ctx, "GetOverdueReviews", err)
+	}
+	defer rows.Close()
+
+	var reviews []domain.PendingReview
+	for rows.Next() {
+		var review domain.PendingReview
+		if err := rows.Scan(&review.PullRequestID, &review.ReviewerID, &review.AssignedAt); err != nil {
+			return nil, queryError(ctx, "GetOverdueReviews", err)
+		}
+		reviews = append(reviews, review)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, "GetOverdueReviews", err)
+	}
+
+	return reviews, nil
+}
+
+func (r *PostgresRepository) SavePR(ctx context.Context, pr *domain.PullRequest, events []domain.ReviewerEvent) error {
+	defer r.observe("SavePR", time.Now())
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return queryError(ctx, "SavePR", err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				slog.ErrorContext(ctx, "rollback error", "error", rbErr)
+			}
+		}
+	}()
+
+	reviewerTeams, err := encodeReviewerTeams(pr.ReviewerTeams)
+	if err != nil {
+		return queryError(ctx, "SavePR", err)
+	}
+
+	extraTeams := pr.ExtraTeams
+	if extraTeams == nil {
+		extraTeams = []string{}
+	}
+	paths := pr.Paths
+	if paths == nil {
+		paths = []string{}
+	}
+
+	// paths are fixed at creation and only loaded by GetPRAndTeam, so an
+	// update leaves them as they are.
+	query := `
+		INSERT INTO pull_requests (id, title, author_id, reviewers_id, is_merged, merged_at, created_at, reviewer_teams, extra_teams, paths) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		ON CONFLICT (id) DO UPDATE SET 
 			title = EXCLUDED.title,
 			author_id = EXCLUDED.author_id,
 			reviewers_id = EXCLUDED.reviewers_id,
+			reviewer_teams = EXCLUDED.reviewer_teams,
+			extra_teams = EXCLUDED.extra_teams,
 			is_merged = EXCLUDED.is_merged,
 			merged_at = EXCLUDED.merged_at`
 
-	_, err := r.db.ExecContext(ctx, query, 
+	_, err = tx.ExecContext(ctx, query, 
 		pr.ID, 
 		pr.Title, 
 		pr.AuthorID, 
 		pq.Array(pr.ReviewersID), 
 		bool(pr.Status),
 		pr.MergedAt,
+		pr.CreatedAt,
+		reviewerTeams,
+		pq.Array(extraTeams),
+		pq.Array(paths),
 	)
 	if err != nil {
-		return service.ErrQueryExecution
+		return queryError(ctx, "SavePR", err)
+	}
+
+	eventQuery := `
+		INSERT INTO reviewer_events (pull_request_id, user_id, action, reason, created_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	for _, event := range events {
+		_, err = tx.ExecContext(ctx, eventQuery,
+			event.PullRequestID,
+			event.UserID,
+			event.Action,
+			event.Reason,
+			event.CreatedAt,
+		)
+		if err != nil {
+			return queryError(ctx, "SavePR", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return queryError(ctx, "SavePR", err)
 	}
 
 	return nil
 }
 
-func (r *PostgresRepository) scanPullRequest(scanner interface {
+func (r *PostgresRepository) scanPullRequest(ctx context.Context, scanner interface {
 	Scan(dest ...interface{}) error
 }) (*domain.PullRequest, error) {
 	var pr domain.PullRequest
 	var reviewers []string
 	var isMerged bool
 	var mergedAt *time.Time
+	var createdAt time.Time
+	var reviewerTeams []byte
+	var extraTeams []string
 
 	err := scanner.Scan(
 		&pr.ID,
@@ -176,17 +414,41 @@ func (r *PostgresRepository) scanPullRequest(scanner interface {
 		pq.Array(&reviewers),
 		&isMerged,
 		&mergedAt,
+		&createdAt,
+		&reviewerTeams,
+		pq.Array(&extraTeams),
 	)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
-		return nil, service.ErrQueryExecution
+		return nil, queryError(ctx, "scanPullRequest", err)
 	}
 
 	pr.ReviewersID = reviewers
 	pr.Status = domain.PRStatus(isMerged)
 	pr.MergedAt = mergedAt
+	pr.CreatedAt = createdAt
+	pr.ExtraTeams = extraTeams
+	pr.ReviewerTeams, err = decodeReviewerTeams(reviewerTeams)
+	if err != nil {
+		return nil, queryError(ctx, "scanPullRequest", err)
+	}
 	return &pr, nil
+}
+
+func decodeReviewerTeams(data []byte) (map[string]string, error) {
+	teams := make(map[string]string)
+	if err := json.Unmarshal(data, &teams); err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+func encodeReviewerTeams(teams map[string]string) ([]byte, error) {
+	if teams == nil {
+		teams = map[string]string{}
+	}
+	return json.Marshal(teams)
 }
\ No newline at end of file
@@ -3,23 +3,31 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/J0hnLenin/ReviewRequest/domain"
 	"github.com/J0hnLenin/ReviewRequest/service"
 )
 
-func (r *PostgresRepository) GetStatistics(ctx context.Context) (*domain.Statistics, error) {
+// createdIn and mergedIn restrict a statistics query to the period passed as $1 and $2.
+const (
+	createdIn = `($1::timestamptz IS NULL OR pr.created_at >= $1) AND ($2::timestamptz IS NULL OR pr.created_at < $2)`
+	mergedIn  = `($1::timestamptz IS NULL OR pr.merged_at >= $1) AND ($2::timestamptz IS NULL OR pr.merged_at < $2)`
+)
+
+func (r *PostgresRepository) GetStatistics(ctx context.Context, period domain.TimeRange) (*domain.Statistics, error) {
+	defer r.observe("GetStatistics", time.Now())
 	// 1. Получаем общее количество открытых и закрытых PR
 	totalQuery := `
 		SELECT 
-			COUNT(*) FILTER (WHERE NOT is_merged) as open_prs,
-			COUNT(*) FILTER (WHERE is_merged) as closed_prs
-		FROM pull_requests`
+			COUNT(*) FILTER (WHERE NOT pr.is_merged AND ` + createdIn + `) as open_prs,
+			COUNT(*) FILTER (WHERE pr.is_merged AND ` + mergedIn + `) as closed_prs
+		FROM pull_requests pr`
 
 	var openPRs, closedPRs int
-	err := r.db.QueryRowContext(ctx, totalQuery).Scan(&openPRs, &closedPRs)
+	err := r.db.QueryRowContext(ctx, totalQuery, period.From, period.To).Scan(&openPRs, &closedPRs)
 	if err != nil {
-		return nil, service.ErrQueryExecution
+		return nil, queryError(ctx, "GetStatistics", err)
 	}
 
 	// 2. Получаем ревьюера с наибольшим количеством открытых PR
@@ -28,19 +36,19 @@ func (r *PostgresRepository) GetStatistics(ctx context.Context) (*domain.Statist
 		FROM pull_requests pr
 		CROSS JOIN UNNEST(pr.reviewers_id) AS reviewer_id
 		JOIN users u ON u.id = reviewer_id
-		WHERE NOT pr.is_merged
+		WHERE NOT pr.is_merged AND ` + createdIn + `
 		GROUP BY u.id, u.user_name
 		ORDER BY pr_count DESC
 		LIMIT 1`
 
 	var topOpenReviewer domain.UserStats
-	err = r.db.QueryRowContext(ctx, topOpenReviewerQuery).Scan(
+	err = r.db.QueryRowContext(ctx, topOpenReviewerQuery, period.From, period.To).Scan(
 		&topOpenReviewer.UserID,
 		&topOpenReviewer.UserName,
 		&topOpenReviewer.Count,
 	)
 	if err != nil && err != sql.ErrNoRows {
-		return nil, service.ErrQueryExecution
+		return nil, queryError(ctx, "GetStatistics", err)
 	}
 
 	// 3. Получаем ревьюера с наибольшим количеством закрытых PR
@@ -49,19 +57,19 @@ func (r *PostgresRepository) GetStatistics(ctx context.Context) (*domain.Statist
 		FROM pull_requests pr
 		CROSS JOIN UNNEST(pr.reviewers_id) AS reviewer_id
 		JOIN users u ON u.id = reviewer_id
-		WHERE pr.is_merged
+		WHERE pr.is_merged AND ` + mergedIn + `
 		GROUP BY u.id, u.user_name
 		ORDER BY pr_count DESC
 		LIMIT 1`
 
 	var topClosedReviewer domain.UserStats
-	err = r.db.QueryRowContext(ctx, topClosedReviewerQuery).Scan(
+	err = r.db.QueryRowContext(ctx, topClosedReviewerQuery, period.From, period.To).Scan(
 		&topClosedReviewer.UserID,
 		&topClosedReviewer.UserName,
 		&topClosedReviewer.Count,
 	)
 	if err != nil && err != sql.ErrNoRows {
-		return nil, service.ErrQueryExecution
+		return nil, queryError(ctx, "GetStatistics", err)
 	}
 
 	// 4. Получаем автора с наибольшим количеством PR
@@ -69,18 +77,19 @@ func (r *PostgresRepository) GetStatistics(ctx context.Context) (*domain.Statist
 		SELECT u.id, u.user_name, COUNT(*) as pr_count
 		FROM pull_requests pr
 		JOIN users u ON u.id = pr.author_id
+		WHERE ` + createdIn + `
 		GROUP BY u.id, u.user_name
 		ORDER BY pr_count DESC
 		LIMIT 1`
 
 	var topAuthor domain.UserStats
-	err = r.db.QueryRowContext(ctx, topAuthorQuery).Scan(
+	err = r.db.QueryRowContext(ctx, topAuthorQuery, period.From, period.To).Scan(
 		&topAuthor.UserID,
 		&topAuthor.UserName,
 		&topAuthor.Count,
 	)
 	if err != nil && err != sql.ErrNoRows {
-		return nil, service.ErrQueryExecution
+		return nil, queryError(ctx, "GetStatistics", err)
 	}
 
 	stats := &domain.Statistics{
@@ -100,4 +109,392 @@ func (r *PostgresRepository) GetStatistics(ctx context.Context) (*domain.Statist
 	}
 
 	return stats, nil
-}
\ No newline at end of file
+}
+
+func (r *PostgresRepository) GetOpenReviewLoads(ctx context.Context, period domain.TimeRange) ([]int, error) {
+	defer r.observe("GetOpenReviewLoads", time.Now())
+	query := `
+		SELECT COUNT(pr.id)
+		FROM users u
+		LEFT JOIN pull_requests pr ON NOT pr.is_merged AND u.id = ANY(pr.reviewers_id) AND ` + createdIn + `
+		WHERE u.is_active
+		GROUP BY u.id`
+
+	rows, err := r.db.QueryContext(ctx, query, period.From, period.To)
+	if err != nil {
+		return nil, queryError(ctx, "GetOpenReviewLoads", err)
+	}
+	defer rows.Close()
+
+	loads := []int{}
+	for rows.Next() {
+		var load int
+		if err := rows.Scan(&load); err != nil {
+			return nil, queryError(ctx, "GetOpenReviewLoads", err)
+		}
+		loads = append(loads, load)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, "GetOpenReviewLoads", err)
+	}
+
+	return loads, nil
+}
+
+// eventIn restricts reviewer events e to the period passed as $1 and $2.
+const eventIn = `($1::timestamptz IS NULL OR e.created_at >= $1) AND ($2::timestamptz IS NULL OR e.created_at < $2)`
+
+func (r *PostgresRepository) GetUserProfile(ctx context.Context, userID string, period domain.TimeRange) (*domain.UserProfile, error) {
+	defer r.observe("GetUserProfile", time.Now())
+	query := `
+		SELECT
+			u.id,
+			u.user_name,
+			(SELECT COUNT(*) FROM pull_requests pr
+				WHERE pr.author_id = u.id AND NOT pr.is_merged AND ` + createdIn + `),
+			(SELECT COUNT(*) FROM pull_requests pr
+				WHERE pr.author_id = u.id AND pr.is_merged AND ` + mergedIn + `),
+			(SELECT COUNT(*) FROM pull_requests pr
+				WHERE u.id = ANY(pr.reviewers_id) AND NOT pr.is_merged AND ` + createdIn + `),
+			(SELECT COUNT(*) FROM pull_requests pr
+				WHERE u.id = ANY(pr.reviewers_id) AND pr.is_merged AND ` + mergedIn + `),
+			(SELECT COUNT(*) FROM reviewer_events e
+				WHERE e.user_id = u.id AND e.action = 'replaced' AND ` + eventIn + `),
+			(SELECT COUNT(*) FROM reviewer_events e
+				WHERE e.user_id = u.id AND e.action = 'declined' AND ` + eventIn + `),
+			(SELECT AVG(EXTRACT(EPOCH FROM pr.merged_at - pr.created_at))::float8 FROM pull_requests pr
+				WHERE u.id = ANY(pr.reviewers_id) AND pr.is_merged AND ` + mergedIn + `)
+		FROM users u
+		WHERE u.id = $3`
+
+	var profile domain.UserProfile
+	err := r.db.QueryRowContext(ctx, query, period.From, period.To, userID).Scan(
+		&profile.UserID,
+		&profile.UserName,
+		&profile.AuthoredOpen,
+		&profile.AuthoredMerged,
+		&profile.ReviewsOpen,
+		&profile.ReviewsCompleted,
+		&profile.ReassignedAway,
+		&profile.Declined,
+		&profile.AvgTimeToMergeSeconds,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, queryError(ctx, "GetUserProfile", err)
+	}
+
+	return &profile, nil
+}
+
+// leaderboardQueries count per user the PRs each leaderboard metric ranks by.
+var leaderboardQueries = map[domain.LeaderboardMetric]string{
+	domain.LeaderboardOpenReviews: `
+		SELECT u.id, u.user_name, COUNT(*) as pr_count
+		FROM pull_requests pr
+		CROSS JOIN UNNEST(pr.reviewers_id) AS reviewer_id
+		JOIN users u ON u.id = reviewer_id
+		WHERE NOT pr.is_merged AND ` + createdIn + `
+		GROUP BY u.id, u.user_name`,
+	domain.LeaderboardClosedReviews: `
+		SELECT u.id, u.user_name, COUNT(*) as pr_count
+		FROM pull_requests pr
+		CROSS JOIN UNNEST(pr.reviewers_id) AS reviewer_id
+		JOIN users u ON u.id = reviewer_id
+		WHERE pr.is_merged AND ` + mergedIn + `
+		GROUP BY u.id, u.user_name`,
+	domain.LeaderboardAuthored: `
+		SELECT u.id, u.user_name, COUNT(*) as pr_count
+		FROM pull_requests pr
+		JOIN users u ON u.id = pr.author_id
+		WHERE ` + createdIn + `
+		GROUP BY u.id, u.user_name`,
+}
+
+func (r *PostgresRepository) GetLeaderboard(ctx context.Context, metric domain.LeaderboardMetric, period domain.TimeRange, limit int, offset int) ([]domain.LeaderboardEntry, int, error) {
+	defer r.observe("GetLeaderboard", time.Now())
+	counts, ok := leaderboardQueries[metric]
+	if !ok {
+		return nil, 0, service.ErrQueryExecution
+	}
+
+	// Ранг считается по всему списку до пагинации, поэтому равные значения
+	// получают одинаковый ранг и на разных страницах
+	query := `
+		SELECT
+			c.id,
+			c.user_name,
+			c.pr_count,
+			RANK() OVER (ORDER BY c.pr_count DESC),
+			COUNT(*) OVER ()
+		FROM (` + counts + `) c
+		ORDER BY c.pr_count DESC, c.id
+		LIMIT $3 OFFSET $4`
+
+	rows, err := r.db.QueryContext(ctx, query, period.From, period.To, limit, offset)
+	if err != nil {
+		return nil, 0, queryError(ctx, "GetLeaderboard", err)
+	}
+	defer rows.Close()
+
+	var entries []domain.LeaderboardEntry
+	total := 0
+	for rows.Next() {
+		var entry domain.LeaderboardEntry
+		if err := rows.Scan(&entry.UserID, &entry.UserName, &entry.Count, &entry.Rank, &total); err != nil {
+			return nil, 0, queryError(ctx, "GetLeaderboard", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, queryError(ctx, "GetLeaderboard", err)
+	}
+
+	if len(entries) == 0 && offset > 0 {
+		// Страница за концом списка: общее число берём отдельным запросом
+		err = r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM (`+counts+`) c`, period.From, period.To).Scan(&total)
+		if err != nil {
+			return nil, 0, queryError(ctx, "GetLeaderboard", err)
+		}
+	}
+
+	return entries, total, nil
+}
+
+func (r *PostgresRepository) GetTeamStatistics(ctx context.Context, teamName string) (*domain.TeamStatistics, error) {
+	defer r.observe("GetTeamStatistics", time.Now())
+	// 1. Считаем открытые и слитые PR авторов команды
+	totalQuery := `
+		SELECT 
+			COUNT(pr.id) FILTER (WHERE NOT pr.is_merged) as open_prs,
+			COUNT(pr.id) FILTER (WHERE pr.is_merged) as merged_prs
+		FROM teams t
+		LEFT JOIN users u ON u.team_name = t.team_name
+		LEFT JOIN pull_requests pr ON pr.author_id = u.id
+		WHERE t.team_name = $1
+		GROUP BY t.team_name`
+
+	stats := &domain.TeamStatistics{
+		TeamName:   teamName,
+		MemberLoad: []domain.MemberLoad{},
+	}
+	err := r.db.QueryRowContext(ctx, totalQuery, teamName).Scan(&stats.OpenPRs, &stats.MergedPRs)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, queryError(ctx, "GetTeamStatistics", err)
+	}
+
+	// 2. Считаем открытые ревью каждого участника, включая участников без ревью
+	loadQuery := `
+		SELECT u.id, u.user_name, u.is_active, COUNT(pr.id) as pr_count
+		FROM users u
+		LEFT JOIN pull_requests pr ON NOT pr.is_merged AND u.id = ANY(pr.reviewers_id)
+		WHERE u.team_name = $1
+		GROUP BY u.id, u.user_name, u.is_active
+		ORDER BY u.id`
+
+	rows, err := r.db.QueryContext(ctx, loadQuery, teamName)
+	if err != nil {
+		return nil, queryError(ctx, "GetTeamStatistics", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var load domain.MemberLoad
+		if err := rows.Scan(&load.UserID, &load.UserName, &load.IsActive, &load.Count); err != nil {
+			return nil, queryError(ctx, "GetTeamStatistics", err)
+		}
+		stats.MemberLoad = append(stats.MemberLoad, load)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, "GetTeamStatistics", err)
+	}
+
+	return stats, nil
+}
+
+func (r *PostgresRepository) GetReviewLoad(ctx context.Context) (*domain.ReviewLoad, error) {
+	defer r.observe("GetReviewLoad", time.Now())
+	load := &domain.ReviewLoad{TeamReviews: make(map[string]int)}
+
+	openQuery := `SELECT COUNT(*) FROM pull_requests WHERE NOT is_merged`
+	if err := r.db.QueryRowContext(ctx, openQuery).Scan(&load.OpenPRs); err != nil {
+		return nil, queryError(ctx, "GetReviewLoad", err)
+	}
+
+	// Считаем открытые ревью участников каждой команды, включая команды без ревью
+	teamQuery := `
+		SELECT t.team_name, COUNT(pr.id)
+		FROM teams t
+		LEFT JOIN users u ON u.team_name = t.team_name
+		LEFT JOIN pull_requests pr ON NOT pr.is_merged AND u.id = ANY(pr.reviewers_id)
+		GROUP BY t.team_name`
+
+	rows, err := r.db.QueryContext(ctx, teamQuery)
+	if err != nil {
+		return nil, queryError(ctx, "GetReviewLoad", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var team string
+		var count int
+		if err := rows.Scan(&team, &count); err != nil {
+			return nil, queryError(ctx, "GetReviewLoad", err)
+		}
+		load.TeamReviews[team] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, "GetReviewLoad", err)
+	}
+
+	return load, nil
+}
+
+// reviewDecisions lists the reviewer_events actions that record a review decision.
+const reviewDecisions = `('approved', 'changes_requested')`
+
+func (r *PostgresRepository) GetPRTimelines(ctx context.Context, period domain.TimeRange) ([]domain.PRTimeline, error) {
+	defer r.observe("GetPRTimelines", time.Now())
+	// 1. Получаем время создания, первого ревью и слияния каждого PR
+	prQuery := `
+		SELECT
+			pr.id,
+			u.team_name,
+			pr.created_at,
+			(
+				SELECT MIN(e.created_at)
+				FROM reviewer_events e
+				WHERE e.pull_request_id = pr.id AND e.action IN ` + reviewDecisions + `
+			),
+			pr.merged_at
+		FROM pull_requests pr
+		JOIN users u ON u.id = pr.author_id
+		WHERE ` + createdIn + `
+		ORDER BY pr.created_at`
+
+	rows, err := r.db.QueryContext(ctx, prQuery, period.From, period.To)
+	if err != nil {
+		return nil, queryError(ctx, "GetPRTimelines", err)
+	}
+	defer rows.Close()
+
+	var timelines []domain.PRTimeline
+	index := make(map[string]int)
+	for rows.Next() {
+		var pr domain.PRTimeline
+		if err := rows.Scan(&pr.PullRequestID, &pr.TeamName, &pr.CreatedAt, &pr.FirstReviewAt, &pr.MergedAt); err != nil {
+			return nil, queryError(ctx, "GetPRTimelines", err)
+		}
+		index[pr.PullRequestID] = len(timelines)
+		timelines = append(timelines, pr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, "GetPRTimelines", err)
+	}
+
+	// 2. Получаем первое решение каждого ревьюера и время его назначения
+	reviewQuery := `
+		SELECT
+			d.pull_request_id,
+			d.user_id,
+			COALESCE(a.assigned_at, pr.created_at),
+			d.decided_at
+		FROM (
+			SELECT pull_request_id, user_id, MIN(created_at) AS decided_at
+			FROM reviewer_events
+			WHERE action IN ` + reviewDecisions + `
+			GROUP BY pull_request_id, user_id
+		) d
+		JOIN pull_requests pr ON pr.id = d.pull_request_id
+		CROSS JOIN LATERAL (
+			SELECT MAX(e.created_at) AS assigned_at
+			FROM reviewer_events e
+			WHERE e.pull_request_id = d.pull_request_id
+				AND e.user_id = d.user_id
+				AND e.action IN ('assigned', 'added')
+				AND e.created_at <= d.decided_at
+		) a
+		WHERE ` + createdIn
+
+	reviewRows, err := r.db.QueryContext(ctx, reviewQuery, period.From, period.To)
+	if err != nil {
+		return nil, queryError(ctx, "GetPRTimelines", err)
+	}
+	defer reviewRows.Close()
+
+	for reviewRows.Next() {
+		var prID string
+		var review domain.ReviewTiming
+		if err := reviewRows.Scan(&prID, &review.ReviewerID, &review.AssignedAt, &review.DecidedAt); err != nil {
+			return nil, queryError(ctx, "GetPRTimelines", err)
+		}
+		if i, ok := index[prID]; ok {
+			timelines[i].Reviews = append(timelines[i].Reviews, review)
+		}
+	}
+	if err := reviewRows.Err(); err != nil {
+		return nil, queryError(ctx, "GetPRTimelines", err)
+	}
+
+	return timelines, nil
+}
+
+// timeSeriesQueries select the event time of each metric as ts. They take
+// the period as $1 and $2 and an optional author team name as $3.
+var timeSeriesQueries = map[domain.TimeSeriesMetric]string{
+	domain.SeriesPRsCreated: `
+		SELECT pr.created_at AS ts
+		FROM pull_requests pr
+		JOIN users u ON u.id = pr.author_id
+		WHERE ` + createdIn + ` AND ($3::text = '' OR u.team_name = $3)`,
+	domain.SeriesPRsMerged: `
+		SELECT pr.merged_at AS ts
+		FROM pull_requests pr
+		JOIN users u ON u.id = pr.author_id
+		WHERE pr.is_merged AND ` + mergedIn + ` AND ($3::text = '' OR u.team_name = $3)`,
+	domain.SeriesReviewsAssigned: `
+		SELECT e.created_at AS ts
+		FROM reviewer_events e
+		JOIN pull_requests pr ON pr.id = e.pull_request_id
+		JOIN users u ON u.id = pr.author_id
+		WHERE e.action IN ('assigned', 'added') AND ` + eventIn + ` AND ($3::text = '' OR u.team_name = $3)`,
+}
+
+func (r *PostgresRepository) GetTimeSeries(ctx context.Context, metric domain.TimeSeriesMetric, bucket domain.TimeBucket, teamName string, period domain.TimeRange) ([]domain.TimeSeriesPoint, error) {
+	defer r.observe("GetTimeSeries", time.Now())
+	events, ok := timeSeriesQueries[metric]
+	if !ok {
+		return nil, service.ErrQueryExecution
+	}
+
+	query := `
+		SELECT date_trunc($4::text, s.ts AT TIME ZONE 'UTC') AS bucket_start, COUNT(*)
+		FROM (` + events + `) s
+		GROUP BY bucket_start
+		ORDER BY bucket_start`
+
+	rows, err := r.db.QueryContext(ctx, query, period.From, period.To, teamName, string(bucket))
+	if err != nil {
+		return nil, queryError(ctx, "GetTimeSeries", err)
+	}
+	defer rows.Close()
+
+	var points []domain.TimeSeriesPoint
+	for rows.Next() {
+		var point domain.TimeSeriesPoint
+		if err := rows.Scan(&point.Start, &point.Count); err != nil {
+			return nil, queryError(ctx, "GetTimeSeries", err)
+		}
+		points = append(points, point)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, "GetTimeSeries", err)
+	}
+
+	return points, nil
+}
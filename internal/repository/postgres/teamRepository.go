@@ -3,120 +3,131 @@ package postgres
 import (
 	"context"
 	"database/sql"
-	"log"
+	"log/slog"
+	"time"
 
 	"github.com/J0hnLenin/ReviewRequest/domain"
-	"github.com/J0hnLenin/ReviewRequest/service"
 	"github.com/lib/pq"
 )
 
 func (r *PostgresRepository) GetTeamByName(ctx context.Context, name string) (*domain.Team, error) {
+	defer r.observe("GetTeamByName", time.Now())
 	query := `
-		SELECT t.team_name, 
-		       COALESCE(array_agg(u.id ORDER BY u.id) FILTER (WHERE u.id IS NOT NULL), '{}') as member_ids,
-		       COALESCE(array_agg(u.user_name ORDER BY u.id) FILTER (WHERE u.id IS NOT NULL), '{}') as member_names,
-		       COALESCE(array_agg(u.is_active ORDER BY u.id) FILTER (WHERE u.id IS NOT NULL), '{}') as member_active
+		SELECT ` + teamColumns + `
 		FROM teams t
-		LEFT JOIN users u ON t.team_name = u.team_name
+		LEFT JOIN users um ON t.team_name = um.team_name
 		WHERE t.team_name = $1
 		GROUP BY t.team_name`
 
-	var team domain.Team
-	var memberIDs, memberNames []string
-	var memberActive []bool
+	var row teamRow
 
-	err := r.db.QueryRowContext(ctx, query, name).Scan(
-		&team.Name,
-		pq.Array(&memberIDs),
-		pq.Array(&memberNames),
-		pq.Array(&memberActive),
-	)
+	err := r.db.QueryRowContext(ctx, query, name).Scan(row.dest()...)
 
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	if err != nil {
-		return nil, service.ErrQueryExecution
+		return nil, queryError(ctx, "GetTeamByName", err)
+	}
+
+	team, err := row.team()
+	if err != nil {
+		return nil, queryError(ctx, "GetTeamByName", err)
+	}
+
+	return team, nil
+}
+
+func (r *PostgresRepository) GetTeamsByNames(ctx context.Context, names []string) ([]*domain.Team, error) {
+	defer r.observe("GetTeamsByNames", time.Now())
+	query := `
+		SELECT ` + teamColumns + `
+		FROM teams t
+		LEFT JOIN users um ON t.team_name = um.team_name
+		WHERE t.team_name = ANY($1)
+		GROUP BY t.team_name`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(names))
+	if err != nil {
+		return nil, queryError(ctx, "GetTeamsByNames", err)
 	}
+	defer rows.Close()
 
-	team.Members = make([]*domain.User, len(memberIDs))
-	for i := range memberIDs {
-		team.Members[i] = &domain.User{
-			ID:       memberIDs[i],
-			Name:     memberNames[i],
-			TeamName: team.Name,
-			IsActive: memberActive[i],
+	var teams []*domain.Team
+	for rows.Next() {
+		var row teamRow
+		if err := rows.Scan(row.dest()...); err != nil {
+			return nil, queryError(ctx, "GetTeamsByNames", err)
 		}
+		team, err := row.team()
+		if err != nil {
+			return nil, queryError(ctx, "GetTeamsByNames", err)
+		}
+		teams = append(teams, team)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, "GetTeamsByNames", err)
 	}
 
-	return &team, nil
+	return teams, nil
 }
 
 func (r *PostgresRepository) GetTeamByUser(ctx context.Context, userID string) (*domain.Team, error) {
+	defer r.observe("GetTeamByUser", time.Now())
 	query := `
-		SELECT 
-			t.team_name,
-			COALESCE(array_agg(um.id ORDER BY um.id) FILTER (WHERE um.id IS NOT NULL), '{}') as member_ids,
-			COALESCE(array_agg(um.user_name ORDER BY um.id) FILTER (WHERE um.id IS NOT NULL), '{}') as member_names,
-			COALESCE(array_agg(um.is_active ORDER BY um.id) FILTER (WHERE um.id IS NOT NULL), '{}') as member_active
+		SELECT ` + teamColumns + `
 		FROM teams t
 		INNER JOIN users u ON u.team_name = t.team_name AND u.id = $1
 		LEFT JOIN users um ON t.team_name = um.team_name
 		GROUP BY t.team_name`
 
-	var team domain.Team
-	var memberIDs, memberNames []string
-	var memberActive []bool
+	var row teamRow
 
-	err := r.db.QueryRowContext(ctx, query, userID).Scan(
-		&team.Name,
-		pq.Array(&memberIDs),
-		pq.Array(&memberNames),
-		pq.Array(&memberActive),
-	)
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(row.dest()...)
 
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	if err != nil {
-		return nil, service.ErrQueryExecution
+		return nil, queryError(ctx, "GetTeamByUser", err)
 	}
 
-	team.Members = make([]*domain.User, len(memberIDs))
-	for i := range memberIDs {
-		team.Members[i] = &domain.User{
-			ID:       memberIDs[i],
-			Name:     memberNames[i],
-			TeamName: team.Name,
-			IsActive: memberActive[i],
-		}
+	team, err := row.team()
+	if err != nil {
+		return nil, queryError(ctx, "GetTeamByUser", err)
 	}
 
-	return &team, nil
+	return team, nil
 }
 
 func (r *PostgresRepository) SaveTeam(ctx context.Context, t *domain.Team) error {
+	defer r.observe("SaveTeam", time.Now())
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return service.ErrQueryExecution
+		return queryError(ctx, "SaveTeam", err)
 	}
 	defer func() {
 		if err != nil {
 			if rbErr := tx.Rollback(); rbErr != nil {
-				log.Printf("rollback error: %v", rbErr)
+				slog.ErrorContext(ctx, "rollback error", "error", rbErr)
 			}
 		}
 	}()
 
-	query := `INSERT INTO teams (team_name) VALUES ($1) ON CONFLICT (team_name) DO NOTHING`
-	_, err = tx.ExecContext(ctx, query, t.Name)
+	requirements, err := encodeRoleRequirements(t.RoleRequirements)
 	if err != nil {
-		return service.ErrQueryExecution
+		return queryError(ctx, "SaveTeam", err)
+	}
+
+	query := `INSERT INTO teams (team_name, role_requirements) VALUES ($1, $2) ON CONFLICT (team_name) DO NOTHING`
+	_, err = tx.ExecContext(ctx, query, t.Name, requirements)
+	if err != nil {
+		return queryError(ctx, "SaveTeam", err)
 	}
 
 	for _, user := range t.Members {
-		if err := r.saveUser(ctx, tx, user); err != nil {
-			return service.ErrQueryExecution
+		if err := r.saveMember(ctx, tx, user); err != nil {
+			return queryError(ctx, "SaveTeam", err)
 		}
 	}
 
@@ -124,14 +135,15 @@ func (r *PostgresRepository) SaveTeam(ctx context.Context, t *domain.Team) error
 }
 
 func (r *PostgresRepository) ChangeTeamActive(ctx context.Context, name string, active bool) (*domain.Team, error) {
+	defer r.observe("ChangeTeamActive", time.Now())
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return nil, service.ErrQueryExecution
+		return nil, queryError(ctx, "ChangeTeamActive", err)
 	}
 	defer func() {
 		if err != nil {
 			if rbErr := tx.Rollback(); rbErr != nil {
-				log.Printf("rollback error: %v", rbErr)
+				slog.ErrorContext(ctx, "rollback error", "error", rbErr)
 			}
 		}
 	}()
@@ -143,53 +155,123 @@ func (r *PostgresRepository) ChangeTeamActive(ctx context.Context, name string,
 
 	_, err = tx.ExecContext(ctx, updateQuery, active, name)
 	if err != nil {
-		return nil, service.ErrQueryExecution
+		return nil, queryError(ctx, "ChangeTeamActive", err)
 	}
 
 	teamQuery := `
-        SELECT t.team_name, 
-               COALESCE(array_agg(u.id ORDER BY u.id) FILTER (WHERE u.id IS NOT NULL), '{}') as member_ids,
-               COALESCE(array_agg(u.user_name ORDER BY u.id) FILTER (WHERE u.id IS NOT NULL), '{}') as member_names,
-               COALESCE(array_agg(u.is_active ORDER BY u.id) FILTER (WHERE u.id IS NOT NULL), '{}') as member_active
+        SELECT ` + teamColumns + `
         FROM teams t
-        LEFT JOIN users u ON t.team_name = u.team_name
+        LEFT JOIN users um ON t.team_name = um.team_name
         WHERE t.team_name = $1
         GROUP BY t.team_name`
 
-	var team domain.Team
-	var memberIDs, memberNames []string
-	var memberActive []bool
+	var row teamRow
 
-	err = tx.QueryRowContext(ctx, teamQuery, name).Scan(
-		&team.Name,
-		pq.Array(&memberIDs),
-		pq.Array(&memberNames),
-		pq.Array(&memberActive),
-	)
+	err = tx.QueryRowContext(ctx, teamQuery, name).Scan(row.dest()...)
 
 	if err == sql.ErrNoRows {
 		if rbErr := tx.Rollback(); rbErr != nil {
-			log.Printf("rollback error: %v", rbErr)
+			slog.ErrorContext(ctx, "rollback error", "error", rbErr)
 		}
 		return nil, nil
 	}
 	if err != nil {
-		return nil, service.ErrQueryExecution
+		return nil, queryError(ctx, "ChangeTeamActive", err)
 	}
 
-	team.Members = make([]*domain.User, len(memberIDs))
-	for i := range memberIDs {
-		team.Members[i] = &domain.User{
-			ID:       memberIDs[i],
-			Name:     memberNames[i],
-			TeamName: team.Name,
-			IsActive: memberActive[i],
-		}
+	team, err := row.team()
+	if err != nil {
+		return nil, queryError(ctx, "ChangeTeamActive", err)
 	}
 
 	if err := tx.Commit(); err != nil {
-		return nil, service.ErrQueryExecution
+		return nil, queryError(ctx, "ChangeTeamActive", err)
+	}
+
+	return team, nil
+}
+
+func (r *PostgresRepository) SaveTeamOwnerRules(ctx context.Context, name string, rules []domain.OwnerRule) error {
+	defer r.observe("SaveTeamOwnerRules", time.Now())
+	data, err := encodeOwnerRules(rules)
+	if err != nil {
+		return queryError(ctx, "SaveTeamOwnerRules", err)
+	}
+
+	query := `UPDATE teams SET owner_rules = $2 WHERE team_name = $1`
+	_, err = r.db.ExecContext(ctx, query, name, data)
+	if err != nil {
+		return queryError(ctx, "SaveTeamOwnerRules", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresRepository) SaveTeamRoleRequirements(ctx context.Context, name string, requirements []domain.RoleRequirement) error {
+	defer r.observe("SaveTeamRoleRequirements", time.Now())
+	data, err := encodeRoleRequirements(requirements)
+	if err != nil {
+		return queryError(ctx, "SaveTeamRoleRequirements", err)
+	}
+
+	query := `UPDATE teams SET role_requirements = $2 WHERE team_name = $1`
+	_, err = r.db.ExecContext(ctx, query, name, data)
+	if err != nil {
+		return queryError(ctx, "SaveTeamRoleRequirements", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresRepository) SaveTeamSLA(ctx context.Context, name string, policy domain.SLAPolicy) error {
+	defer r.observe("SaveTeamSLA", time.Now())
+	query := `UPDATE teams SET sla_hours = $2, sla_action = $3 WHERE team_name = $1`
+	_, err := r.db.ExecContext(ctx, query, name, policy.Hours, policy.Action)
+	if err != nil {
+		return queryError(ctx, "SaveTeamSLA", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresRepository) AdvanceRoundRobinCursor(ctx context.Context, teamName string, advance func(cursor string) (string, error)) error {
+	defer r.observe("AdvanceRoundRobinCursor", time.Now())
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return queryError(ctx, "AdvanceRoundRobinCursor", err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				slog.ErrorContext(ctx, "rollback error", "error", rbErr)
+			}
+		}
+	}()
+
+	var cursor string
+	query := `SELECT round_robin_cursor FROM teams WHERE team_name = $1 FOR UPDATE`
+	err = tx.QueryRowContext(ctx, query, teamName).Scan(&cursor)
+	if err == sql.ErrNoRows {
+		return domain.ErrNotFound
+	}
+	if err != nil {
+		return queryError(ctx, "AdvanceRoundRobinCursor", err)
+	}
+
+	next, err := advance(cursor)
+	if err != nil {
+		return err
+	}
+
+	updateQuery := `UPDATE teams SET round_robin_cursor = $2 WHERE team_name = $1`
+	_, err = tx.ExecContext(ctx, updateQuery, teamName, next)
+	if err != nil {
+		return queryError(ctx, "AdvanceRoundRobinCursor", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return queryError(ctx, "AdvanceRoundRobinCursor", err)
 	}
 
-	return &team, nil
+	return nil
 }
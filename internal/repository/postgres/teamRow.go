@@ -0,0 +1,175 @@
+package postgres
+
+import (
+	"encoding/json"
+
+	"github.com/J0hnLenin/ReviewRequest/domain"
+	"github.com/lib/pq"
+)
+
+// teamColumns selects team t with its members joined under the alias um, ordered by id.
+const teamColumns = `
+	t.team_name,
+	t.owner_rules,
+	t.role_requirements,
+	t.sla_hours,
+	t.sla_action,
+	t.round_robin_cursor,
+	COALESCE(array_agg(um.id ORDER BY um.id) FILTER (WHERE um.id IS NOT NULL), '{}') as member_ids,
+	COALESCE(array_agg(um.user_name ORDER BY um.id) FILTER (WHERE um.id IS NOT NULL), '{}') as member_names,
+	COALESCE(array_agg(um.is_active ORDER BY um.id) FILTER (WHERE um.id IS NOT NULL), '{}') as member_active,
+	COALESCE(array_agg(um.timezone ORDER BY um.id) FILTER (WHERE um.id IS NOT NULL), '{}') as member_timezones,
+	COALESCE(array_agg(um.work_start ORDER BY um.id) FILTER (WHERE um.id IS NOT NULL), '{}') as member_work_start,
+	COALESCE(array_agg(um.work_end ORDER BY um.id) FILTER (WHERE um.id IS NOT NULL), '{}') as member_work_end,
+	COALESCE(json_agg(um.tags ORDER BY um.id) FILTER (WHERE um.id IS NOT NULL), '[]') as member_tags,
+	COALESCE(array_agg(um.role ORDER BY um.id) FILTER (WHERE um.id IS NOT NULL), '{}') as member_roles,
+	COALESCE(array_agg(um.review_weight ORDER BY um.id) FILTER (WHERE um.id IS NOT NULL), '{}') as member_weights`
+
+type ownerRuleRow struct {
+	Pattern string   `json:"pattern"`
+	UserIDs []string `json:"users"`
+	Tags    []string `json:"tags"`
+}
+
+type roleRequirementRow struct {
+	Role  string `json:"role"`
+	Count int    `json:"count"`
+}
+
+type teamRow struct {
+	name             string
+	ownerRules       []byte
+	roleRequirements []byte
+	slaHours         int
+	slaAction        string
+	roundRobinCursor string
+	ids              []string
+	names            []string
+	active           []bool
+	timezones        []string
+	workStart        []int64
+	workEnd          []int64
+	tags             []byte
+	roles            []string
+	weights          []int64
+}
+
+func (t *teamRow) dest() []interface{} {
+	return []interface{}{
+		&t.name,
+		&t.ownerRules,
+		&t.roleRequirements,
+		&t.slaHours,
+		&t.slaAction,
+		&t.roundRobinCursor,
+		pq.Array(&t.ids),
+		pq.Array(&t.names),
+		pq.Array(&t.active),
+		pq.Array(&t.timezones),
+		pq.Array(&t.workStart),
+		pq.Array(&t.workEnd),
+		&t.tags,
+		pq.Array(&t.roles),
+		pq.Array(&t.weights),
+	}
+}
+
+func (t *teamRow) team() (*domain.Team, error) {
+	var memberTags [][]string
+	if err := json.Unmarshal(t.tags, &memberTags); err != nil {
+		return nil, err
+	}
+	rules, err := decodeOwnerRules(t.ownerRules)
+	if err != nil {
+		return nil, err
+	}
+	requirements, err := decodeRoleRequirements(t.roleRequirements)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*domain.User, len(t.ids))
+	for i := range t.ids {
+		weight := int(t.weights[i])
+		members[i] = &domain.User{
+			ID:       t.ids[i],
+			Name:     t.names[i],
+			TeamName: t.name,
+			IsActive: t.active[i],
+			WorkingHours: domain.WorkingHours{
+				TimeZone: t.timezones[i],
+				Start:    int(t.workStart[i]),
+				End:      int(t.workEnd[i]),
+			},
+			Tags:         memberTags[i],
+			Role:         domain.Role(t.roles[i]),
+			ReviewWeight: &weight,
+		}
+	}
+
+	return &domain.Team{
+		Name:             t.name,
+		Members:          members,
+		OwnerRules:       rules,
+		RoleRequirements: requirements,
+		SLA: domain.SLAPolicy{
+			Hours:  t.slaHours,
+			Action: domain.SLAAction(t.slaAction),
+		},
+		RoundRobinCursor: t.roundRobinCursor,
+	}, nil
+}
+
+func decodeOwnerRules(data []byte) ([]domain.OwnerRule, error) {
+	var rows []ownerRuleRow
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, err
+	}
+	rules := make([]domain.OwnerRule, len(rows))
+	for i, row := range rows {
+		rules[i] = domain.OwnerRule{
+			Pattern: row.Pattern,
+			UserIDs: row.UserIDs,
+			Tags:    row.Tags,
+		}
+	}
+	return rules, nil
+}
+
+func encodeOwnerRules(rules []domain.OwnerRule) ([]byte, error) {
+	rows := make([]ownerRuleRow, len(rules))
+	for i, rule := range rules {
+		rows[i] = ownerRuleRow{
+			Pattern: rule.Pattern,
+			UserIDs: rule.UserIDs,
+			Tags:    rule.Tags,
+		}
+	}
+	return json.Marshal(rows)
+}
+
+func decodeRoleRequirements(data []byte) ([]domain.RoleRequirement, error) {
+	var rows []roleRequirementRow
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, err
+	}
+	requirements := make([]domain.RoleRequirement, len(rows))
+	for i, row := range rows {
+		requirements[i] = domain.RoleRequirement{
+			MinRole: domain.Role(row.Role),
+			Count:   row.Count,
+		}
+	}
+	return requirements, nil
+}
+
+func encodeRoleRequirements(requirements []domain.RoleRequirement) ([]byte, error) {
+	rows := make([]roleRequirementRow, len(requirements))
+	for i, req := range requirements {
+		rows[i] = roleRequirementRow{
+			Role:  string(req.MinRole),
+			Count: req.Count,
+		}
+	}
+	return json.Marshal(rows)
+}
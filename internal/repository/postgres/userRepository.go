@@ -3,14 +3,16 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/J0hnLenin/ReviewRequest/domain"
-	"github.com/J0hnLenin/ReviewRequest/service"
+	"github.com/lib/pq"
 )
 
 func (r *PostgresRepository) GetUserById(ctx context.Context, id string) (*domain.User, error) {
+	defer r.observe("GetUserById", time.Now())
 	query := `
-		SELECT id, user_name, team_name, is_active 
+		SELECT id, user_name, team_name, is_active, timezone, work_start, work_end, tags, role, review_weight 
 		FROM users 
 		WHERE id = $1`
 
@@ -20,19 +22,26 @@ func (r *PostgresRepository) GetUserById(ctx context.Context, id string) (*domai
 		&user.Name,
 		&user.TeamName,
 		&user.IsActive,
+		&user.WorkingHours.TimeZone,
+		&user.WorkingHours.Start,
+		&user.WorkingHours.End,
+		pq.Array(&user.Tags),
+		&user.Role,
+		&user.ReviewWeight,
 	)
 
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	if err != nil {
-		return nil, service.ErrQueryExecution
+		return nil, queryError(ctx, "GetUserById", err)
 	}
 
 	return &user, nil
 }
 
 func (r *PostgresRepository) SaveUser(ctx context.Context, u *domain.User) error {
+	defer r.observe("SaveUser", time.Now())
 	return r.saveUser(ctx, r.db, u)
 }
 
@@ -40,17 +49,97 @@ func (r *PostgresRepository) saveUser(ctx context.Context, execer interface {
 	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
 }, u *domain.User) error {
 	query := `
-		INSERT INTO users (id, user_name, team_name, is_active) 
-		VALUES ($1, $2, $3, $4) 
+		INSERT INTO users (id, user_name, team_name, is_active, timezone, work_start, work_end, tags, role, review_weight) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, COALESCE($10, 1)) 
 		ON CONFLICT (id) DO UPDATE SET 
+			review_weight = EXCLUDED.review_weight,
 			user_name = EXCLUDED.user_name,
 			team_name = EXCLUDED.team_name,
-			is_active = EXCLUDED.is_active`
+			is_active = EXCLUDED.is_active,
+			timezone = EXCLUDED.timezone,
+			work_start = EXCLUDED.work_start,
+			work_end = EXCLUDED.work_end,
+			tags = EXCLUDED.tags,
+			role = EXCLUDED.role`
 
-	_, err := execer.ExecContext(ctx, query, u.ID, u.Name, u.TeamName, u.IsActive)
+	tags := u.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	_, err := execer.ExecContext(ctx, query,
+		u.ID,
+		u.Name,
+		u.TeamName,
+		u.IsActive,
+		u.WorkingHours.TimeZone,
+		u.WorkingHours.Start,
+		u.WorkingHours.End,
+		pq.Array(tags),
+		u.Role,
+		u.ReviewWeight,
+	)
+	if err != nil {
+		return queryError(ctx, "saveUser", err)
+	}
+
+	return nil
+}
+
+// saveMember upserts a member sent to /team/add. Optional fields the request
+// left out keep their stored values, or get the column defaults for a new user.
+func (r *PostgresRepository) saveMember(ctx context.Context, execer interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+}, u *domain.User) error {
+	query := `
+		INSERT INTO users (id, user_name, team_name, is_active, timezone, work_start, work_end, tags, role, review_weight) 
+		VALUES ($1, $2, $3, $4, COALESCE($5, ''), COALESCE($6::smallint, 0), COALESCE($7::smallint, 0),
+			COALESCE($8::varchar[], '{}'), COALESCE($9, ''), COALESCE($10, 1)) 
+		ON CONFLICT (id) DO UPDATE SET 
+			user_name = EXCLUDED.user_name,
+			team_name = EXCLUDED.team_name,
+			is_active = EXCLUDED.is_active,
+			timezone = COALESCE($5, users.timezone),
+			work_start = COALESCE($6::smallint, users.work_start),
+			work_end = COALESCE($7::smallint, users.work_end),
+			tags = COALESCE($8::varchar[], users.tags),
+			role = COALESCE($9, users.role),
+			review_weight = COALESCE($10, users.review_weight)`
+
+	_, err := execer.ExecContext(ctx, query, memberArgs(u)...)
 	if err != nil {
-		return service.ErrQueryExecution
+		return queryError(ctx, "saveMember", err)
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// memberArgs binds u for saveMember: absent working hours (no time zone),
+// tags, role and review weight are passed as NULL.
+func memberArgs(u *domain.User) []interface{} {
+	var timezone, workStart, workEnd, role, weight interface{}
+	if u.WorkingHours.TimeZone != "" {
+		timezone = u.WorkingHours.TimeZone
+		workStart = u.WorkingHours.Start
+		workEnd = u.WorkingHours.End
+	}
+	if u.Role != "" {
+		role = string(u.Role)
+	}
+	if u.ReviewWeight != nil {
+		weight = *u.ReviewWeight
+	}
+
+	return []interface{}{
+		u.ID,
+		u.Name,
+		u.TeamName,
+		u.IsActive,
+		timezone,
+		workStart,
+		workEnd,
+		pq.Array(u.Tags),
+		role,
+		weight,
+	}
+}
@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/J0hnLenin/ReviewRequest/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemberArgs_AbsentOptionalFieldsAreNull(t *testing.T) {
+	args := memberArgs(&domain.User{ID: "u1", Name: "Alice", TeamName: "backend", IsActive: true})
+
+	require.Len(t, args, 10)
+	assert.Equal(t, []interface{}{"u1", "Alice", "backend", true}, args[:4])
+	assert.Nil(t, args[4], "timezone")
+	assert.Nil(t, args[5], "work_start")
+	assert.Nil(t, args[6], "work_end")
+	assert.Nil(t, arrayValue(t, args[7]), "tags")
+	assert.Nil(t, args[8], "role")
+	assert.Nil(t, args[9], "review_weight")
+}
+
+func TestMemberArgs_PresentOptionalFieldsAreBound(t *testing.T) {
+	weight := 0
+	args := memberArgs(&domain.User{
+		ID:           "u1",
+		Name:         "Alice",
+		TeamName:     "backend",
+		IsActive:     true,
+		WorkingHours: domain.WorkingHours{TimeZone: "Europe/Moscow", Start: 9, End: 18},
+		Tags:         []string{},
+		Role:         domain.RoleSenior,
+		ReviewWeight: &weight,
+	})
+
+	require.Len(t, args, 10)
+	assert.Equal(t, "Europe/Moscow", args[4])
+	assert.Equal(t, 9, args[5])
+	assert.Equal(t, 18, args[6])
+	assert.Equal(t, "{}", arrayValue(t, args[7]), "an explicit empty list clears the tags")
+	assert.Equal(t, "senior", args[8])
+	assert.Equal(t, 0, args[9])
+}
+
+func arrayValue(t *testing.T, arg interface{}) driver.Value {
+	valuer, ok := arg.(driver.Valuer)
+	require.True(t, ok)
+	value, err := valuer.Value()
+	require.NoError(t, err)
+	return value
+}
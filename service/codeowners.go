@@ -0,0 +1,141 @@
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/J0hnLenin/ReviewRequest/domain"
+)
+
+const tagOwnerPrefix = "tag:"
+
+// ParseCodeowners reads CODEOWNERS-formatted rules. Owners are written as
+// @user_id or tag:<expertise>; a pattern without owners clears ownership
+// for the paths it matches.
+func ParseCodeowners(r io.Reader) ([]domain.OwnerRule, error) {
+	rules := make([]domain.OwnerRule, 0)
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+		if ind := strings.Index(line, "#"); ind >= 0 {
+			line = line[:ind]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if !validOwnerPattern(fields[0]) {
+			return nil, fmt.Errorf("%w: bad pattern on line %d", domain.ErrInvalidCodeowners, lineNumber)
+		}
+		rule := domain.OwnerRule{Pattern: fields[0]}
+
+		for _, owner := range fields[1:] {
+			switch {
+			case strings.HasPrefix(owner, "@") && len(owner) > 1:
+				rule.UserIDs = append(rule.UserIDs, owner[1:])
+			case strings.HasPrefix(owner, tagOwnerPrefix) && len(owner) > len(tagOwnerPrefix):
+				rule.Tags = append(rule.Tags, owner[len(tagOwnerPrefix):])
+			default:
+				return nil, fmt.Errorf("%w: bad owner %q on line %d", domain.ErrInvalidCodeowners, owner, lineNumber)
+			}
+		}
+		rules = append(rules, rule)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidCodeowners, err)
+	}
+
+	return rules, nil
+}
+
+func validOwnerPattern(pattern string) bool {
+	for _, segment := range strings.Split(strings.Trim(pattern, "/"), "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// matchOwnerPattern follows gitignore semantics: a leading or inner slash
+// anchors the pattern to the repository root, a trailing slash matches only
+// directories, "**" spans any number of directories, and a pattern matching
+// a directory also matches everything below it.
+func matchOwnerPattern(pattern string, file string) bool {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+
+	segments := strings.Split(strings.Trim(pattern, "/"), "/")
+	if !anchored {
+		segments = append([]string{"**"}, segments...)
+	}
+
+	return matchSegments(segments, strings.Split(strings.Trim(file, "/"), "/"), dirOnly)
+}
+
+func matchSegments(pattern []string, file []string, dirOnly bool) bool {
+	if len(pattern) == 0 {
+		return !dirOnly || len(file) > 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(file); i++ {
+			if matchSegments(pattern[1:], file[i:], dirOnly) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(file) == 0 {
+		return false
+	}
+	matched, err := path.Match(pattern[0], file[0])
+	if err != nil || !matched {
+		return false
+	}
+	return matchSegments(pattern[1:], file[1:], dirOnly)
+}
+
+func ownerRuleFor(rules []domain.OwnerRule, file string) *domain.OwnerRule {
+	for i := len(rules) - 1; i >= 0; i-- {
+		if matchOwnerPattern(rules[i].Pattern, file) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+// requiredOwners returns the distinct rules that own at least one of the
+// changed paths, in order of first appearance.
+func requiredOwners(rules []domain.OwnerRule, paths []string) []*domain.OwnerRule {
+	required := make([]*domain.OwnerRule, 0)
+	for _, file := range paths {
+		rule := ownerRuleFor(rules, file)
+		if rule == nil || (len(rule.UserIDs) == 0 && len(rule.Tags) == 0) {
+			continue
+		}
+		if !slices.Contains(required, rule) {
+			required = append(required, rule)
+		}
+	}
+	return required
+}
+
+func ownsRule(rule *domain.OwnerRule, u *domain.User) bool {
+	if slices.Contains(rule.UserIDs, u.ID) {
+		return true
+	}
+	for _, tag := range u.Tags {
+		if slices.Contains(rule.Tags, tag) {
+			return true
+		}
+	}
+	return false
+}
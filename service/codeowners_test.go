@@ -0,0 +1,91 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/J0hnLenin/ReviewRequest/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCodeowners_Success(t *testing.T) {
+	file := `
+# default owners
+*            @u1
+
+/web/        tag:frontend @u2
+*.sql        tag:dba      # migrations too
+/docs/generated/
+`
+	rules, err := ParseCodeowners(strings.NewReader(file))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.OwnerRule{
+		{Pattern: "*", UserIDs: []string{"u1"}},
+		{Pattern: "/web/", UserIDs: []string{"u2"}, Tags: []string{"frontend"}},
+		{Pattern: "*.sql", Tags: []string{"dba"}},
+		{Pattern: "/docs/generated/"},
+	}, rules)
+}
+
+func TestParseCodeowners_BadOwner(t *testing.T) {
+	rules, err := ParseCodeowners(strings.NewReader("*.go @u1\n*.js frontend\n"))
+
+	assert.Nil(t, rules)
+	assert.ErrorIs(t, err, domain.ErrInvalidCodeowners)
+	assert.Contains(t, err.Error(), "line 2")
+}
+
+func TestParseCodeowners_BadPattern(t *testing.T) {
+	rules, err := ParseCodeowners(strings.NewReader("src/[a-.go @u1\n"))
+
+	assert.Nil(t, rules)
+	assert.ErrorIs(t, err, domain.ErrInvalidCodeowners)
+}
+
+func TestMatchOwnerPattern(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		file     string
+		expected bool
+	}{
+		{"*", "main.go", true},
+		{"*", "internal/api/handler.go", true},
+		{"*.sql", "internal/repository/postgres/migrations/000_reset.sql", true},
+		{"*.sql", "internal/repository/postgres/postgres.go", false},
+		{"/web/", "web/src/app.tsx", true},
+		{"/web/", "api/web/app.go", false},
+		{"web/", "web", false},
+		{"docs", "docs/openapi.yml", true},
+		{"docs", "api/docs/readme.md", true},
+		{"/app/main.go", "app/main.go", true},
+		{"internal/*.go", "internal/api/handler.go", false},
+		{"internal/**/*.go", "internal/api/handler/handler.go", true},
+		{"**/migrations", "internal/repository/postgres/migrations/001.sql", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.pattern+" "+tc.file, func(t *testing.T) {
+			assert.Equal(t, tc.expected, matchOwnerPattern(tc.pattern, tc.file))
+		})
+	}
+}
+
+func TestRequiredOwners_LastMatchWins(t *testing.T) {
+	rules := []domain.OwnerRule{
+		{Pattern: "*", UserIDs: []string{"u1"}},
+		{Pattern: "/web/", Tags: []string{"frontend"}},
+		{Pattern: "/web/generated/"},
+	}
+
+	required := requiredOwners(rules, []string{
+		"web/app.tsx",
+		"web/style.css",
+		"web/generated/api.ts",
+		"README.md",
+	})
+
+	assert.Len(t, required, 2)
+	assert.Equal(t, "/web/", required[0].Pattern)
+	assert.Equal(t, "*", required[1].Pattern)
+}
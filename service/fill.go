@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/J0hnLenin/ReviewRequest/domain"
+)
+
+// topUpQueueSize bounds the teams waiting for a background top-up. Requests
+// beyond it are dropped: the queued run for the same team covers them.
+const topUpQueueSize = 64
+
+// PRFill assigns reviewers to the empty slots of an open PR, left because
+// candidates were scarce when it was created. It returns the added reviewers;
+// a PR that is already full is returned unchanged. When the team's role
+// requirements still cannot be met, the reviewers found are saved anyway and
+// the PR is returned together with an error wrapping ErrRoleRequirement.
+func (s *Service) PRFill(ctx context.Context, prID string) (*domain.PullRequest, []string, error) {
+	pr, team, err := s.repo.GetPRAndTeam(ctx, prID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if pr == nil || team == nil {
+		return nil, nil, domain.ErrNotFound
+	}
+	if pr.Status == domain.Merged {
+		return nil, nil, domain.ErrPRMerged
+	}
+
+	added, err := s.fillPR(ctx, pr, team, time.Now())
+	if errors.Is(err, domain.ErrRoleRequirement) {
+		return pr, added, err
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return pr, added, nil
+}
+
+// fillPR saves the reviewers added to the PR's empty slots. A role
+// requirement error is returned after saving whatever could be added.
+func (s *Service) fillPR(ctx context.Context, pr *domain.PullRequest, team *domain.Team, now time.Time) ([]string, error) {
+	extraTeams, err := s.prExtraTeams(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := slices.Clone(pr.ReviewersID)
+	a := s.assignment(team, prOptions(pr, team), now)
+	a.extraTeams = extraTeams
+	fillErr := a.fillReviewers(pr)
+	if fillErr != nil && !errors.Is(fillErr, domain.ErrRoleRequirement) {
+		return nil, fillErr
+	}
+	added := pr.ReviewersID[len(existing):]
+	if len(added) == 0 {
+		return nil, fillErr
+	}
+
+	pr.Explanation = a.explain(pr, existing, added)
+	events := make([]domain.ReviewerEvent, 0, len(added))
+	for _, reviewerID := range added {
+		events = append(events, reviewerEvent(pr, reviewerID, domain.ReviewerAssigned, now))
+	}
+	if err := s.repo.SavePR(ctx, pr, events); err != nil {
+		return nil, err
+	}
+	return added, fillErr
+}
+
+// prExtraTeams loads the teams the PR asked for cross-team reviewers, in
+// the order they were requested, skipping teams that no longer exist.
+func (s *Service) prExtraTeams(ctx context.Context, pr *domain.PullRequest) ([]*domain.Team, error) {
+	if len(pr.ExtraTeams) == 0 {
+		return nil, nil
+	}
+	teams, err := s.repo.GetTeamsByNames(ctx, pr.ExtraTeams)
+	if err != nil {
+		return nil, err
+	}
+	ordered := make([]*domain.Team, 0, len(pr.ExtraTeams))
+	for _, name := range pr.ExtraTeams {
+		if ind := slices.IndexFunc(teams, func(t *domain.Team) bool { return t.Name == name }); ind != -1 {
+			ordered = append(ordered, teams[ind])
+		}
+	}
+	return ordered, nil
+}
+
+// TopUpTeam fills every open under-reviewed PR that waits for a reviewer
+// from the team, either as the author's team or as an extra team, and
+// returns how many of them got new reviewers.
+func (s *Service) TopUpTeam(ctx context.Context, teamName string) (int, error) {
+	ids, err := s.repo.GetUnderReviewedPRs(ctx, teamName, domain.MaxReviewers, authorSlotsWithExtraTeams)
+	if err != nil {
+		return 0, err
+	}
+
+	filled := 0
+	for _, id := range ids {
+		pr, team, err := s.repo.GetPRAndTeam(ctx, id)
+		if err != nil {
+			return filled, err
+		}
+		if pr == nil || team == nil || pr.Status == domain.Merged {
+			continue
+		}
+		added, err := s.fillPR(ctx, pr, team, time.Now())
+		if errors.Is(err, domain.ErrRoleRequirement) {
+			slog.WarnContext(ctx, "top-up: role requirements still unmet", "pull_request_id", id, "error", err)
+		} else if err != nil {
+			slog.ErrorContext(ctx, "top-up: fill PR failed", "pull_request_id", id, "error", err)
+			continue
+		}
+		if len(added) > 0 {
+			filled++
+		}
+	}
+	return filled, nil
+}
+
+// RunTopUpWorker tops up the teams scheduled by membership changes until ctx
+// is cancelled.
+func (s *Service) RunTopUpWorker(ctx context.Context) {
+	for {
+		var teamName string
+		select {
+		case <-ctx.Done():
+			return
+		case teamName = <-s.topUps:
+		}
+		filled, err := s.TopUpTeam(ctx, teamName)
+		if err != nil {
+			slog.ErrorContext(ctx, "top-up worker: team failed", "team_name", teamName, "error", err)
+			continue
+		}
+		if filled > 0 {
+			slog.InfoContext(ctx, "top-up worker: filled PRs", "team_name", teamName, "filled", filled)
+		}
+	}
+}
+
+// scheduleTopUp asks the worker to top up the team's PRs without waiting for it.
+func (s *Service) scheduleTopUp(teamName string) {
+	select {
+	case s.topUps <- teamName:
+	default:
+	}
+}
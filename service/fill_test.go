@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/J0hnLenin/ReviewRequest/domain"
+	"github.com/J0hnLenin/ReviewRequest/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func shortHandedPR(id string, reviewers ...string) *domain.PullRequest {
+	return &domain.PullRequest{
+		ID:          id,
+		AuthorID:    "author1",
+		Status:      domain.Open,
+		ReviewersID: reviewers,
+	}
+}
+
+func TestPRFill_AddsMissingReviewer(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	pr := shortHandedPR("pr-1", "user1")
+
+	mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, simulationTeam(), nil)
+	mockRepo.On("SavePR", mock.Anything, pr, mock.MatchedBy(func(events []domain.ReviewerEvent) bool {
+		return len(events) == 1 && events[0].Action == domain.ReviewerAssigned
+	})).Return(nil)
+
+	// Act
+	result, added, err := service.PRFill(context.Background(), pr.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, added, 1)
+	assert.Contains(t, []string{"user2", "user3"}, added[0])
+	assert.Equal(t, []string{"user1", added[0]}, result.ReviewersID)
+	assert.NotNil(t, result.Explanation)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPRFill_FullPRUnchanged(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	pr := shortHandedPR("pr-1", "user1", "user2")
+
+	mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, simulationTeam(), nil)
+
+	// Act
+	result, added, err := service.PRFill(context.Background(), pr.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, added)
+	assert.Equal(t, []string{"user1", "user2"}, result.ReviewersID)
+	mockRepo.AssertNotCalled(t, "SavePR")
+}
+
+func TestPRFill_FillsMissingExtraTeamSlot(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	pr := shortHandedPR("pr-1", "user1")
+	pr.ReviewerTeams = map[string]string{"user1": "team1"}
+	pr.ExtraTeams = []string{"design"}
+
+	mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, simulationTeam(), nil)
+	mockRepo.On("GetTeamsByNames", mock.Anything, []string{"design"}).Return([]*domain.Team{crossTeam()}, nil)
+	mockRepo.On("SavePR", mock.Anything, pr, mock.Anything).Return(nil)
+
+	// Act
+	result, added, err := service.PRFill(context.Background(), pr.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, added, 1)
+	assert.Contains(t, []string{"design1", "design2"}, added[0])
+	assert.Equal(t, "design", result.ReviewerTeams[added[0]])
+	assert.Len(t, result.ReviewersID, 2)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPRFill_PicksOwnerOfStoredPaths(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	team := simulationTeam()
+	team.OwnerRules = []domain.OwnerRule{{Pattern: "/api/", UserIDs: []string{"user3"}}}
+	pr := shortHandedPR("pr-1", "user1")
+	pr.Paths = []string{"api/handler.go"}
+
+	mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, team, nil)
+	mockRepo.On("SavePR", mock.Anything, pr, mock.Anything).Return(nil)
+
+	// Act
+	result, added, err := service.PRFill(context.Background(), pr.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user3"}, added)
+	assert.Equal(t, []string{"user1", "user3"}, result.ReviewersID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPRFill_SavesPartialFillWhenRolesUnmet(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	team := simulationTeam()
+	team.RoleRequirements = []domain.RoleRequirement{{MinRole: domain.RoleSenior, Count: 1}}
+	pr := shortHandedPR("pr-1")
+
+	mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, team, nil)
+	mockRepo.On("SavePR", mock.Anything, pr, mock.MatchedBy(func(events []domain.ReviewerEvent) bool {
+		return len(events) == 1
+	})).Return(nil)
+
+	// Act
+	result, added, err := service.PRFill(context.Background(), pr.ID)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrRoleRequirement)
+	assert.Len(t, added, 1)
+	assert.Equal(t, added, result.ReviewersID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPRFill_Merged(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	pr := shortHandedPR("pr-1")
+	pr.Status = domain.Merged
+
+	mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, simulationTeam(), nil)
+
+	// Act
+	result, _, err := service.PRFill(context.Background(), pr.ID)
+
+	// Assert
+	assert.Nil(t, result)
+	assert.Equal(t, domain.ErrPRMerged, err)
+	mockRepo.AssertNotCalled(t, "SavePR")
+}
+
+func TestTopUpTeam_FillsUnderReviewedPRs(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	first := shortHandedPR("pr-1")
+	second := shortHandedPR("pr-2", "user1", "user2")
+
+	mockRepo.On("GetUnderReviewedPRs", mock.Anything, "team1", domain.MaxReviewers, authorSlotsWithExtraTeams).Return([]string{"pr-1", "pr-2"}, nil)
+	mockRepo.On("GetPRAndTeam", mock.Anything, "pr-1").Return(first, simulationTeam(), nil)
+	mockRepo.On("GetPRAndTeam", mock.Anything, "pr-2").Return(second, simulationTeam(), nil)
+	mockRepo.On("SavePR", mock.Anything, first, mock.Anything).Return(nil)
+
+	// Act
+	filled, err := service.TopUpTeam(context.Background(), "team1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, filled)
+	assert.Len(t, first.ReviewersID, domain.MaxReviewers)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTopUpTeam_FillsPRsWaitingOnExtraTeam(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	pr := shortHandedPR("pr-1", "user1")
+	pr.ReviewerTeams = map[string]string{"user1": "team1"}
+	pr.ExtraTeams = []string{"design"}
+
+	mockRepo.On("GetUnderReviewedPRs", mock.Anything, "design", domain.MaxReviewers, authorSlotsWithExtraTeams).Return([]string{"pr-1"}, nil)
+	mockRepo.On("GetPRAndTeam", mock.Anything, "pr-1").Return(pr, simulationTeam(), nil)
+	mockRepo.On("GetTeamsByNames", mock.Anything, []string{"design"}).Return([]*domain.Team{crossTeam()}, nil)
+	mockRepo.On("SavePR", mock.Anything, pr, mock.Anything).Return(nil)
+
+	// Act
+	filled, err := service.TopUpTeam(context.Background(), "design")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, filled)
+	assert.Equal(t, "user1", pr.ReviewersID[0])
+	assert.Equal(t, "design", pr.ReviewerTeams[pr.ReviewersID[1]])
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTopUpTeam_QueryError(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+
+	mockRepo.On("GetUnderReviewedPRs", mock.Anything, "team1", domain.MaxReviewers, authorSlotsWithExtraTeams).Return(nil, ErrQueryExecution)
+
+	// Act
+	filled, err := service.TopUpTeam(context.Background(), "team1")
+
+	// Assert
+	assert.Equal(t, 0, filled)
+	assert.Equal(t, ErrQueryExecution, err)
+}
+
+func TestUserChangeActive_SchedulesTopUpOnReactivation(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	user := &domain.User{ID: "user1", TeamName: "team1", IsActive: false}
+
+	mockRepo.On("GetUserById", mock.Anything, user.ID).Return(user, nil)
+	mockRepo.On("SaveUser", mock.Anything, user).Return(nil)
+
+	// Act
+	_, err := service.UserChangeActive(context.Background(), user.ID, true)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "team1", <-service.topUps)
+}
+
+func TestTeamChangeActive_DeactivationSkipsTopUp(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+
+	mockRepo.On("ChangeTeamActive", mock.Anything, "team1", false).Return(simulationTeam(), nil)
+
+	// Act
+	_, err := service.TeamChangeActive(context.Background(), "team1", false)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, service.topUps)
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"math"
+	"slices"
+
+	"github.com/J0hnLenin/ReviewRequest/domain"
+)
+
+// GetLatencyStatistics summarises how long PRs created within period waited
+// for their first review decision and for the merge. Per reviewer, time to
+// first review runs from the reviewer's assignment to their own decision,
+// and time to merge covers the PRs they reviewed.
+func (s *Service) GetLatencyStatistics(ctx context.Context, period domain.TimeRange) (*domain.LatencyStatistics, error) {
+	timelines, err := s.repo.GetPRTimelines(ctx, period)
+	if err != nil {
+		return nil, err
+	}
+
+	global := &latencySamples{}
+	teams := make(map[string]*latencySamples)
+	reviewers := make(map[string]*latencySamples)
+	for _, pr := range timelines {
+		team := samplesFor(teams, pr.TeamName)
+		if pr.FirstReviewAt != nil {
+			firstReview := pr.FirstReviewAt.Sub(pr.CreatedAt).Seconds()
+			global.firstReview = append(global.firstReview, firstReview)
+			team.firstReview = append(team.firstReview, firstReview)
+		}
+		var merge float64
+		if pr.MergedAt != nil {
+			merge = pr.MergedAt.Sub(pr.CreatedAt).Seconds()
+			global.merge = append(global.merge, merge)
+			team.merge = append(team.merge, merge)
+		}
+		for _, review := range pr.Reviews {
+			reviewer := samplesFor(reviewers, review.ReviewerID)
+			reviewer.firstReview = append(reviewer.firstReview, review.DecidedAt.Sub(review.AssignedAt).Seconds())
+			if pr.MergedAt != nil {
+				reviewer.merge = append(reviewer.merge, merge)
+			}
+		}
+	}
+
+	stats := &domain.LatencyStatistics{
+		Global:    global.latency(),
+		Teams:     make(map[string]domain.ReviewLatency, len(teams)),
+		Reviewers: make(map[string]domain.ReviewLatency, len(reviewers)),
+	}
+	for name, samples := range teams {
+		stats.Teams[name] = samples.latency()
+	}
+	for id, samples := range reviewers {
+		stats.Reviewers[id] = samples.latency()
+	}
+	return stats, nil
+}
+
+// latencySamples collects durations in seconds for one group of PRs.
+type latencySamples struct {
+	firstReview []float64
+	merge       []float64
+}
+
+func samplesFor(groups map[string]*latencySamples, key string) *latencySamples {
+	samples, ok := groups[key]
+	if !ok {
+		samples = &latencySamples{}
+		groups[key] = samples
+	}
+	return samples
+}
+
+func (l *latencySamples) latency() domain.ReviewLatency {
+	return domain.ReviewLatency{
+		TimeToFirstReview: latencyPercentiles(l.firstReview),
+		TimeToMerge:       latencyPercentiles(l.merge),
+	}
+}
+
+func latencyPercentiles(samples []float64) domain.LatencyPercentiles {
+	sorted := slices.Clone(samples)
+	slices.Sort(sorted)
+	return domain.LatencyPercentiles{
+		Count: len(sorted),
+		P50:   percentile(sorted, 0.5),
+		P90:   percentile(sorted, 0.9),
+		P99:   percentile(sorted, 0.99),
+	}
+}
+
+// percentile interpolates linearly between the closest ranks of sorted, as
+// Postgres percentile_cont does. It returns 0 for no samples.
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	frac := rank - float64(lower)
+	return sorted[lower] + (sorted[upper]-sorted[lower])*frac
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/J0hnLenin/ReviewRequest/domain"
+	"github.com/J0hnLenin/ReviewRequest/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPercentile(t *testing.T) {
+	sorted := []float64{10, 20, 30, 40, 50, 60, 70, 80, 90, 100}
+
+	assert.Equal(t, 0.0, percentile(nil, 0.5))
+	assert.Equal(t, 7.0, percentile([]float64{7}, 0.99))
+	assert.InDelta(t, 55.0, percentile(sorted, 0.5), 1e-9)
+	assert.InDelta(t, 91.0, percentile(sorted, 0.9), 1e-9)
+	assert.InDelta(t, 99.1, percentile(sorted, 0.99), 1e-9)
+}
+
+func TestGetLatencyStatistics_GroupsByTeamAndReviewer(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	created := time.Date(2025, 3, 3, 10, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := created.Add(d)
+		return &t
+	}
+	timelines := []domain.PRTimeline{
+		{
+			PullRequestID: "pr-1",
+			TeamName:      "backend",
+			CreatedAt:     created,
+			FirstReviewAt: at(time.Hour),
+			MergedAt:      at(4 * time.Hour),
+			Reviews: []domain.ReviewTiming{
+				{ReviewerID: "user1", AssignedAt: created, DecidedAt: *at(time.Hour)},
+				{ReviewerID: "user2", AssignedAt: *at(2 * time.Hour), DecidedAt: *at(3 * time.Hour)},
+			},
+		},
+		{
+			PullRequestID: "pr-2",
+			TeamName:      "backend",
+			CreatedAt:     created,
+			FirstReviewAt: at(3 * time.Hour),
+			Reviews: []domain.ReviewTiming{
+				{ReviewerID: "user1", AssignedAt: created, DecidedAt: *at(3 * time.Hour)},
+			},
+		},
+		{
+			PullRequestID: "pr-3",
+			TeamName:      "frontend",
+			CreatedAt:     created,
+		},
+	}
+	period := domain.TimeRange{}
+
+	mockRepo.On("GetPRTimelines", mock.Anything, period).Return(timelines, nil)
+
+	// Act
+	stats, err := service.GetLatencyStatistics(context.Background(), period)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, stats.Global.TimeToFirstReview.Count)
+	assert.Equal(t, 2*3600.0, stats.Global.TimeToFirstReview.P50)
+	assert.Equal(t, 1, stats.Global.TimeToMerge.Count)
+	assert.Equal(t, 4*3600.0, stats.Global.TimeToMerge.P99)
+
+	assert.Equal(t, 2, stats.Teams["backend"].TimeToFirstReview.Count)
+	assert.Equal(t, domain.ReviewLatency{}, stats.Teams["frontend"])
+
+	assert.Equal(t, 2, stats.Reviewers["user1"].TimeToFirstReview.Count)
+	assert.Equal(t, 2*3600.0, stats.Reviewers["user1"].TimeToFirstReview.P50)
+	assert.Equal(t, 1, stats.Reviewers["user1"].TimeToMerge.Count)
+	assert.Equal(t, 3600.0, stats.Reviewers["user2"].TimeToFirstReview.P50)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetLatencyStatistics_RepositoryError(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+
+	mockRepo.On("GetPRTimelines", mock.Anything, domain.TimeRange{}).Return(nil, ErrQueryExecution)
+
+	// Act
+	stats, err := service.GetLatencyStatistics(context.Background(), domain.TimeRange{})
+
+	// Assert
+	assert.Nil(t, stats)
+	assert.Equal(t, ErrQueryExecution, err)
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+
+	"github.com/J0hnLenin/ReviewRequest/domain"
+)
+
+// Recorder receives the service's business metrics. It must be safe for
+// concurrent use.
+type Recorder interface {
+	// AssignmentOutcome counts reviewer assignment on PR creation by outcome:
+	// "full", "partial" or "no_candidate".
+	AssignmentOutcome(outcome string)
+	// Reassignment counts a reviewer reassignment by mode: "requested" or "automatic".
+	Reassignment(mode string)
+}
+
+type nopRecorder struct{}
+
+func (nopRecorder) AssignmentOutcome(string) {}
+func (nopRecorder) Reassignment(string)      {}
+
+// WithMetrics reports assignment outcomes and reassignments to rec.
+func WithMetrics(rec Recorder) Option {
+	return func(s *Service) {
+		s.metrics = rec
+	}
+}
+
+// ReviewLoad returns the current open PR count and open reviews per team.
+func (s *Service) ReviewLoad(ctx context.Context) (*domain.ReviewLoad, error) {
+	return s.repo.GetReviewLoad(ctx)
+}
+
+const (
+	outcomeFull        = "full"
+	outcomePartial     = "partial"
+	outcomeNoCandidate = "no_candidate"
+)
+
+// assignmentOutcome compares the assigned reviewers with the slots the PR could fill.
+func assignmentOutcome(assigned, slots int) string {
+	switch {
+	case assigned == 0:
+		return outcomeNoCandidate
+	case assigned < slots:
+		return outcomePartial
+	}
+	return outcomeFull
+}
+
+func reassignMode(newReviewerID string) string {
+	if newReviewerID != "" {
+		return "requested"
+	}
+	return "automatic"
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/J0hnLenin/ReviewRequest/domain"
+	"github.com/J0hnLenin/ReviewRequest/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAssignmentOutcome(t *testing.T) {
+	assert.Equal(t, outcomeFull, assignmentOutcome(2, 2))
+	assert.Equal(t, outcomePartial, assignmentOutcome(1, 2))
+	assert.Equal(t, outcomeNoCandidate, assignmentOutcome(0, 2))
+}
+
+func TestPRCreate_CountsAssignmentOutcome(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+	recorder := &mocks.MockRecorder{}
+	service := NewService(mockRepo, WithMetrics(recorder))
+
+	team := &domain.Team{
+		Name: "test-team",
+		Members: []*domain.User{
+			{ID: "user1", TeamName: "test-team", IsActive: true},
+			{ID: "user2", TeamName: "test-team", IsActive: true},
+		},
+	}
+
+	mockRepo.On("GetPRById", mock.Anything, mock.Anything).Return(nil, nil)
+	mockRepo.On("GetTeamByUser", mock.Anything, "user1").Return(team, nil)
+	mockRepo.On("SavePR", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	recorder.On("AssignmentOutcome", outcomePartial).Once()
+	recorder.On("AssignmentOutcome", outcomeNoCandidate).Once()
+
+	// Act
+	_, err := service.PRCreate(context.Background(), "pr-1", "Partial", "user1", domain.AssignmentOptions{})
+	assert.NoError(t, err)
+	team.Members[1].IsActive = false
+	_, err = service.PRCreate(context.Background(), "pr-2", "Nobody", "user1", domain.AssignmentOptions{})
+	assert.NoError(t, err)
+
+	// Assert
+	recorder.AssertExpectations(t)
+	recorder.AssertNotCalled(t, "AssignmentOutcome", outcomeFull)
+}
+
+func TestPRreassign_CountsMode(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+	recorder := &mocks.MockRecorder{}
+	service := NewService(mockRepo, WithMetrics(recorder))
+	pr, team := manualReviewerFixture()
+
+	mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, team, nil)
+	mockRepo.On("GetUserById", mock.Anything, "reviewer").Return(team.Members[1], nil)
+	mockRepo.On("GetUserById", mock.Anything, "candidate").Return(team.Members[2], nil)
+	mockRepo.On("SavePR", mock.Anything, pr, mock.Anything).Return(nil)
+	recorder.On("Reassignment", "requested").Once()
+
+	// Act
+	_, _, err := service.PRreassign(context.Background(), pr.ID, "reviewer", "candidate")
+
+	// Assert
+	assert.NoError(t, err)
+	recorder.AssertExpectations(t)
+}
+
+func TestReviewLoad(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+	service := NewService(mockRepo)
+	load := &domain.ReviewLoad{
+		OpenPRs:     4,
+		TeamReviews: map[string]int{"backend": 6, "frontend": 0},
+	}
+
+	mockRepo.On("GetReviewLoad", mock.Anything).Return(load, nil)
+
+	// Act
+	result, err := service.ReviewLoad(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, load, result)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,13 @@
+package mocks
+
+import (
+	"context"
+)
+
+func (m *MockRepository) TryAdvisoryLock(ctx context.Context, key int64) (func(), bool, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1), args.Error(2)
+	}
+	return args.Get(0).(func()), args.Bool(1), args.Error(2)
+}
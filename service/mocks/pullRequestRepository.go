@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/J0hnLenin/ReviewRequest/domain"
 )
@@ -33,7 +34,38 @@ func (m *MockRepository) GetPRAndTeam(ctx context.Context, id string) (*domain.P
 	return args.Get(0).(*domain.PullRequest), args.Get(1).(*domain.Team), args.Error(2)
 }
 
-func (m *MockRepository) SavePR(ctx context.Context, pr *domain.PullRequest) error {
-	args := m.Called(ctx, pr)
+func (m *MockRepository) GetRecentReviewCounts(ctx context.Context, authorID string, since time.Time) (map[string]int, error) {
+	args := m.Called(ctx, authorID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (m *MockRepository) SavePR(ctx context.Context, pr *domain.PullRequest, events []domain.ReviewerEvent) error {
+	args := m.Called(ctx, pr, events)
 	return args.Error(0)
-}
\ No newline at end of file
+}
+func (m *MockRepository) GetOverdueReviews(ctx context.Context, now time.Time) ([]domain.PendingReview, error) {
+	args := m.Called(ctx, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PendingReview), args.Error(1)
+}
+
+func (m *MockRepository) GetUnderReviewedPRs(ctx context.Context, teamName string, maxReviewers int, crossTeamAuthorSlots int) ([]string, error) {
+	args := m.Called(ctx, teamName, maxReviewers, crossTeamAuthorSlots)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockRepository) ListPRs(ctx context.Context, filter domain.PRFilter) ([]*domain.PullRequest, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.PullRequest), args.Error(1)
+}
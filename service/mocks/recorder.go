@@ -0,0 +1,15 @@
+package mocks
+
+import "github.com/stretchr/testify/mock"
+
+type MockRecorder struct {
+	mock.Mock
+}
+
+func (m *MockRecorder) AssignmentOutcome(outcome string) {
+	m.Called(outcome)
+}
+
+func (m *MockRecorder) Reassignment(mode string) {
+	m.Called(mode)
+}
@@ -6,10 +6,66 @@ import (
 	"github.com/J0hnLenin/ReviewRequest/domain"
 )
 
-func (m *MockRepository) GetStatistics(ctx context.Context) (*domain.Statistics, error) {
-	args := m.Called(ctx)
+func (m *MockRepository) GetStatistics(ctx context.Context, period domain.TimeRange) (*domain.Statistics, error) {
+	args := m.Called(ctx, period)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*domain.Statistics), args.Error(1)
-}
\ No newline at end of file
+}
+
+func (m *MockRepository) GetTeamStatistics(ctx context.Context, teamName string) (*domain.TeamStatistics, error) {
+	args := m.Called(ctx, teamName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TeamStatistics), args.Error(1)
+}
+
+func (m *MockRepository) GetPRTimelines(ctx context.Context, period domain.TimeRange) ([]domain.PRTimeline, error) {
+	args := m.Called(ctx, period)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PRTimeline), args.Error(1)
+}
+
+func (m *MockRepository) GetReviewLoad(ctx context.Context) (*domain.ReviewLoad, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReviewLoad), args.Error(1)
+}
+
+func (m *MockRepository) GetLeaderboard(ctx context.Context, metric domain.LeaderboardMetric, period domain.TimeRange, limit int, offset int) ([]domain.LeaderboardEntry, int, error) {
+	args := m.Called(ctx, metric, period, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.LeaderboardEntry), args.Int(1), args.Error(2)
+}
+
+func (m *MockRepository) GetUserProfile(ctx context.Context, userID string, period domain.TimeRange) (*domain.UserProfile, error) {
+	args := m.Called(ctx, userID, period)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserProfile), args.Error(1)
+}
+
+func (m *MockRepository) GetOpenReviewLoads(ctx context.Context, period domain.TimeRange) ([]int, error) {
+	args := m.Called(ctx, period)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int), args.Error(1)
+}
+
+func (m *MockRepository) GetTimeSeries(ctx context.Context, metric domain.TimeSeriesMetric, bucket domain.TimeBucket, teamName string, period domain.TimeRange) ([]domain.TimeSeriesPoint, error) {
+	args := m.Called(ctx, metric, bucket, teamName, period)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.TimeSeriesPoint), args.Error(1)
+}
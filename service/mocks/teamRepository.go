@@ -14,6 +14,14 @@ func (m *MockRepository) GetTeamByName(ctx context.Context, name string) (*domai
 	return args.Get(0).(*domain.Team), args.Error(1)
 }
 
+func (m *MockRepository) GetTeamsByNames(ctx context.Context, names []string) ([]*domain.Team, error) {
+	args := m.Called(ctx, names)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Team), args.Error(1)
+}
+
 func (m *MockRepository) GetTeamByUser(ctx context.Context, userID string) (*domain.Team, error) {
 	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
@@ -33,4 +41,28 @@ func (m *MockRepository) ChangeTeamActive(ctx context.Context, name string, acti
         return nil, args.Error(1)
     }
     return args.Get(0).(*domain.Team), args.Error(1)
-}
\ No newline at end of file
+}
+
+func (m *MockRepository) SaveTeamOwnerRules(ctx context.Context, name string, rules []domain.OwnerRule) error {
+	args := m.Called(ctx, name, rules)
+	return args.Error(0)
+}
+
+func (m *MockRepository) SaveTeamRoleRequirements(ctx context.Context, name string, requirements []domain.RoleRequirement) error {
+	args := m.Called(ctx, name, requirements)
+	return args.Error(0)
+}
+
+func (m *MockRepository) SaveTeamSLA(ctx context.Context, name string, policy domain.SLAPolicy) error {
+	args := m.Called(ctx, name, policy)
+	return args.Error(0)
+}
+
+func (m *MockRepository) AdvanceRoundRobinCursor(ctx context.Context, teamName string, advance func(cursor string) (string, error)) error {
+	args := m.Called(ctx, teamName)
+	if err := args.Error(1); err != nil {
+		return err
+	}
+	_, err := advance(args.String(0))
+	return err
+}
@@ -2,12 +2,22 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/J0hnLenin/ReviewRequest/domain"
 )
 
-func (s *Service) PRCreate(ctx context.Context, prID string, title string, authorID string) (*domain.PullRequest, error) {
+// PRCreate assigns reviewers to a new PR and saves it. When the team's role
+// requirements cannot be met, the PR is saved with the reviewers found and
+// returned together with an error wrapping ErrRoleRequirement.
+func (s *Service) PRCreate(ctx context.Context, prID string, title string, authorID string, opts domain.AssignmentOptions) (*domain.PullRequest, error) {
+	if !validStrategy(opts.Strategy) {
+		return nil, domain.ErrInvalidStrategy
+	}
 	pr, err := s.repo.GetPRById(ctx, prID)
 	if err != nil {
 		return nil, err
@@ -23,6 +33,21 @@ func (s *Service) PRCreate(ctx context.Context, prID string, title string, autho
 	if team == nil {
 		return nil, domain.ErrNotFound
 	}
+
+	extraTeams, err := s.extraTeams(ctx, team, opts.ExtraTeams)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	a := s.assignment(team, opts, now)
+	a.extraTeams = extraTeams
+	if opts.Strategy == domain.StrategyPairAvoidance {
+		a.recentReviews, err = s.repo.GetRecentReviewCounts(ctx, authorID, pairWindowStart(opts, now))
+		if err != nil {
+			return nil, err
+		}
+	}
 	
 	pr = &domain.PullRequest{
 		ID:       prID,
@@ -31,13 +56,59 @@ func (s *Service) PRCreate(ctx context.Context, prID string, title string, autho
 		Status:   domain.Open,
 		ReviewersID: make([]string, 0, 2),
 		MergedAt: nil,
+		CreatedAt: now,
+		ExtraTeams: opts.ExtraTeams,
+		Paths: opts.Paths,
+	}
+	// fillReviewers only fails on unmet role requirements, which do not stop
+	// the PR from being saved.
+	var roleGap error
+	save := func() error {
+		pr.Explanation = a.explain(pr, nil, pr.ReviewersID)
+		events := make([]domain.ReviewerEvent, 0, len(pr.ReviewersID))
+		for _, reviewerID := range pr.ReviewersID {
+			events = append(events, reviewerEvent(pr, reviewerID, domain.ReviewerAssigned, now))
+		}
+		return s.repo.SavePR(ctx, pr, events)
+	}
+	if opts.Strategy == domain.StrategyRoundRobin {
+		// The PR is saved while the cursor is locked, so the cursor only
+		// moves past reviewers that were actually assigned.
+		err = s.repo.AdvanceRoundRobinCursor(ctx, team.Name, func(cursor string) (string, error) {
+			a.cursor = cursor
+			pr.ReviewersID = pr.ReviewersID[:0]
+			roleGap = a.fillReviewers(pr)
+			if err := save(); err != nil {
+				return "", err
+			}
+			return a.cursor, nil
+		})
+	} else {
+		roleGap = a.fillReviewers(pr)
+		err = save()
 	}
-	fillReviewers(pr, team)
-	err = s.repo.SavePR(ctx, pr)
 	if err != nil {
 		return nil, err
 	}
-	return pr, nil
+	s.metrics.AssignmentOutcome(assignmentOutcome(len(pr.ReviewersID), reviewerSlots(pr)))
+	return pr, roleGap
+}
+
+const maxPRListLimit = 1000
+
+// PRList returns one page of PRs matching filter, newest first.
+func (s *Service) PRList(ctx context.Context, filter domain.PRFilter) ([]*domain.PullRequest, error) {
+	if filter.Limit < 1 || filter.Limit > maxPRListLimit || filter.Offset < 0 {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d, offset not negative", domain.ErrInvalidPRFilter, maxPRListLimit)
+	}
+	prs, err := s.repo.ListPRs(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if prs == nil {
+		prs = []*domain.PullRequest{}
+	}
+	return prs, nil
 }
 
 func (s *Service) PRMerge(ctx context.Context, id string) (*domain.PullRequest, error) {
@@ -56,14 +127,14 @@ func (s *Service) PRMerge(ctx context.Context, id string) (*domain.PullRequest,
 	pr.Status = domain.Merged
 	pr.MergedAt = &now
 	
-	err = s.repo.SavePR(ctx, pr)
+	err = s.repo.SavePR(ctx, pr, nil)
 	if err != nil {
 		return nil, err
 	}
 	return pr, nil
 }
 
-func (s *Service) PRreassign(ctx context.Context, prID string, reviewerID string) (*domain.PullRequest, string, error) {
+func (s *Service) PRreassign(ctx context.Context, prID string, reviewerID string, newReviewerID string) (*domain.PullRequest, string, error) {
 	pr, team, err := s.repo.GetPRAndTeam(ctx, prID)
 	if err != nil {
 		return nil, "", err
@@ -84,17 +155,256 @@ func (s *Service) PRreassign(ctx context.Context, prID string, reviewerID string
 	if !prContainsReviewer(pr, reviewerID) {
 		return nil, "", domain.ErrNotAssigned
 	}
-	newReviewer := newReviewer(team, pr)
-	if newReviewer == nil {
-		return nil, "", domain.ErrNoCandidate
+	team, err = s.representedTeam(ctx, pr, team, reviewerID)
+	if err != nil {
+		return nil, "", err
+	}
+	now := time.Now()
+	var newReviewer *domain.User
+	var explanation *domain.AssignmentExplanation
+	a := s.assignment(team, prOptions(pr, team), now)
+	if newReviewerID != "" {
+		newReviewer, err = s.requestedReviewer(ctx, team, pr, newReviewerID)
+		if err == nil {
+			err = a.requestedReplacementError(pr, reviewerID, newReviewer)
+		}
+	} else {
+		newReviewer, err = a.replacementFor(pr, reviewerID)
+		if err == nil {
+			explanation = a.explain(pr, pr.ReviewersID, []string{newReviewer.ID})
+		}
+	}
+	if err != nil {
+		return nil, "", err
 	}
 	err = replaceReviewer(pr, reviewerID, newReviewer.ID)
 	if err != nil {
 		return nil, "", err
 	}
-	err = s.repo.SavePR(ctx, pr)
+	pr.Explanation = explanation
+	events := []domain.ReviewerEvent{
+		reviewerEvent(pr, reviewerID, domain.ReviewerReplaced, now),
+		reviewerEvent(pr, newReviewer.ID, domain.ReviewerAssigned, now),
+	}
+	err = s.repo.SavePR(ctx, pr, events)
 	if err != nil {
 		return nil, "", err
 	}
+	s.metrics.Reassignment(reassignMode(newReviewerID))
 	return pr, newReviewer.ID, err
-}
\ No newline at end of file
+}
+
+// PRDecline lets an assigned reviewer step down with a reason. A replacement
+// is picked the usual way; if nobody is left the reviewer is simply removed.
+// When no candidate keeps the role requirements met, any candidate takes over
+// and the saved PR is returned together with an error wrapping ErrRoleRequirement.
+func (s *Service) PRDecline(ctx context.Context, prID string, reviewerID string, reason string) (*domain.PullRequest, string, error) {
+	if !validDeclineReason(reason) {
+		return nil, "", domain.ErrInvalidReason
+	}
+	pr, team, err := s.repo.GetPRAndTeam(ctx, prID)
+	if err != nil {
+		return nil, "", err
+	}
+	if pr == nil || team == nil {
+		return nil, "", domain.ErrNotFound
+	}
+	if pr.Status == domain.Merged {
+		return nil, "", domain.ErrPRMerged
+	}
+	if !prContainsReviewer(pr, reviewerID) {
+		return nil, "", domain.ErrNotAssigned
+	}
+	team, err = s.representedTeam(ctx, pr, team, reviewerID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	pr.DeclinedIDs = append(pr.DeclinedIDs, reviewerID)
+	declineEvent := reviewerEvent(pr, reviewerID, domain.ReviewerDeclined, now)
+	declineEvent.Reason = strings.TrimSpace(reason)
+	events := []domain.ReviewerEvent{declineEvent}
+
+	newReviewerID := ""
+	a := s.assignment(team, prOptions(pr, team), now)
+	newReviewer, err := a.replacementFor(pr, reviewerID)
+	var roleGap error
+	if errors.Is(err, domain.ErrRoleRequirement) {
+		roleGap = err
+		newReviewer, err = a.pickOwnerFirst(pr, reviewerID, anyone), nil
+	}
+	switch {
+	case errors.Is(err, domain.ErrNoCandidate):
+		err = removeReviewer(pr, reviewerID)
+	case err == nil:
+		newReviewerID = newReviewer.ID
+		pr.Explanation = a.explain(pr, pr.ReviewersID, []string{newReviewerID})
+		err = replaceReviewer(pr, reviewerID, newReviewerID)
+		events = append(events, reviewerEvent(pr, newReviewerID, domain.ReviewerAssigned, now))
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	err = s.repo.SavePR(ctx, pr, events)
+	if err != nil {
+		return nil, "", err
+	}
+	return pr, newReviewerID, roleGap
+}
+
+// extraTeams loads the teams asked to provide cross-team reviewers, in the
+// order they were requested.
+func (s *Service) extraTeams(ctx context.Context, authorTeam *domain.Team, names []string) ([]*domain.Team, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	if len(names) > domain.MaxExtraTeams {
+		return nil, fmt.Errorf("%w: at most %d teams", domain.ErrInvalidExtraTeams, domain.MaxExtraTeams)
+	}
+	for i, name := range names {
+		if name == "" || name == authorTeam.Name || slices.Contains(names[:i], name) {
+			return nil, fmt.Errorf("%w: %q", domain.ErrInvalidExtraTeams, name)
+		}
+	}
+	if required := requiredCount(authorTeam.RoleRequirements); required > authorSlotsWithExtraTeams {
+		return nil, fmt.Errorf("%w: team %q requires %d reviewers with roles, but extra teams leave it %d",
+			domain.ErrInvalidExtraTeams, authorTeam.Name, required, authorSlotsWithExtraTeams)
+	}
+
+	teams, err := s.repo.GetTeamsByNames(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+	ordered := make([]*domain.Team, len(names))
+	for i, name := range names {
+		ind := slices.IndexFunc(teams, func(t *domain.Team) bool { return t.Name == name })
+		if ind == -1 {
+			return nil, fmt.Errorf("%w: team %q", domain.ErrNotFound, name)
+		}
+		ordered[i] = teams[ind]
+	}
+	return ordered, nil
+}
+
+// representedTeam returns the team a reviewer fills a slot for. Cross-team
+// reviewers are replaced from their own team, whose ownership rules and
+// role requirements only apply to its own PRs.
+func (s *Service) representedTeam(ctx context.Context, pr *domain.PullRequest, authorTeam *domain.Team, reviewerID string) (*domain.Team, error) {
+	name := pr.ReviewerTeams[reviewerID]
+	if name == "" || name == authorTeam.Name {
+		return authorTeam, nil
+	}
+	team, err := s.repo.GetTeamByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return authorTeam, nil
+	}
+	return &domain.Team{
+		Name:    team.Name,
+		Members: team.Members,
+	}, nil
+}
+
+func (s *Service) requestedReviewer(ctx context.Context, team *domain.Team, pr *domain.PullRequest, reviewerID string) (*domain.User, error) {
+	reviewer, err := s.repo.GetUserById(ctx, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+	if reviewer == nil {
+		return nil, domain.ErrNotFound
+	}
+	err = requestedCandidateError(team, pr, reviewer)
+	if err != nil {
+		return nil, err
+	}
+	return reviewer, nil
+}
+
+// PRReview records an assigned reviewer's decision on an open PR.
+func (s *Service) PRReview(ctx context.Context, prID string, reviewerID string, decision domain.ReviewerAction) (*domain.PullRequest, error) {
+	if decision != domain.ReviewerApproved && decision != domain.ReviewerChangesRequested {
+		return nil, domain.ErrInvalidDecision
+	}
+	pr, err := s.repo.GetPRById(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if pr == nil {
+		return nil, domain.ErrNotFound
+	}
+	if pr.Status == domain.Merged {
+		return nil, domain.ErrPRMerged
+	}
+	if !prContainsReviewer(pr, reviewerID) {
+		return nil, domain.ErrNotAssigned
+	}
+
+	events := []domain.ReviewerEvent{
+		reviewerEvent(pr, reviewerID, decision, time.Now()),
+	}
+	err = s.repo.SavePR(ctx, pr, events)
+	if err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+func (s *Service) PRAddReviewer(ctx context.Context, prID string, reviewerID string) (*domain.PullRequest, error) {
+	pr, team, err := s.repo.GetPRAndTeam(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if pr == nil || team == nil {
+		return nil, domain.ErrNotFound
+	}
+	if pr.Status == domain.Merged {
+		return nil, domain.ErrPRMerged
+	}
+	_, err = s.requestedReviewer(ctx, team, pr, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(ownReviewers(pr)) >= authorSlots(pr) {
+		return nil, domain.ErrReviewerLimit
+	}
+
+	addReviewer(pr, reviewerID, team.Name)
+	events := []domain.ReviewerEvent{
+		reviewerEvent(pr, reviewerID, domain.ReviewerAdded, time.Now()),
+	}
+	err = s.repo.SavePR(ctx, pr, events)
+	if err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+func (s *Service) PRRemoveReviewer(ctx context.Context, prID string, reviewerID string) (*domain.PullRequest, error) {
+	pr, err := s.repo.GetPRById(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if pr == nil {
+		return nil, domain.ErrNotFound
+	}
+	if pr.Status == domain.Merged {
+		return nil, domain.ErrPRMerged
+	}
+
+	err = removeReviewer(pr, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+	events := []domain.ReviewerEvent{
+		reviewerEvent(pr, reviewerID, domain.ReviewerRemoved, time.Now()),
+	}
+	err = s.repo.SavePR(ctx, pr, events)
+	if err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
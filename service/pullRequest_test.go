@@ -38,10 +38,10 @@ func TestPRCreate_Success(t *testing.T) {
 			pr.AuthorID == authorID &&
 			len(pr.ReviewersID) == 2 &&
 			pr.Status == domain.Open
-	})).Return(nil)
+	}), mock.Anything).Return(nil)
 
 	// Act
-	pr, err := service.PRCreate(context.Background(), prID, title, authorID)
+	pr, err := service.PRCreate(context.Background(), prID, title, authorID, domain.AssignmentOptions{})
 
 	// Assert
 	assert.NoError(t, err)
@@ -70,7 +70,7 @@ func TestPRCreate_UserNotFound(t *testing.T) {
 	mockRepo.On("GetTeamByUser", mock.Anything, authorID).Return(nil, nil)
 
 	// Act
-	pr, err := service.PRCreate(context.Background(), prID, title, authorID)
+	pr, err := service.PRCreate(context.Background(), prID, title, authorID, domain.AssignmentOptions{})
 
 	// Assert
 	assert.Error(t, err)
@@ -103,7 +103,7 @@ func TestPRCreate_PRAlreadyExists(t *testing.T) {
 	mockRepo.On("GetPRById", mock.Anything, prID).Return(existingPR, nil)
 
 	// Act
-	pr, err := service.PRCreate(context.Background(), prID, title, authorID)
+	pr, err := service.PRCreate(context.Background(), prID, title, authorID, domain.AssignmentOptions{})
 
 	// Assert
 	assert.Error(t, err)
@@ -132,7 +132,7 @@ func TestPRMerge_Success(t *testing.T) {
 	mockRepo.On("GetPRById", mock.Anything, prID).Return(existingPR, nil)
 	mockRepo.On("SavePR", mock.Anything, mock.MatchedBy(func(pr *domain.PullRequest) bool {
 		return pr.Status == domain.Merged && pr.MergedAt != nil
-	})).Return(nil)
+	}), mock.Anything).Return(nil)
 
 	// Act
 	pr, err := service.PRMerge(context.Background(), prID)
@@ -257,10 +257,10 @@ func TestPRreassign_Success(t *testing.T) {
 	mockRepo.On("GetPRAndTeam", mock.Anything, prID).Return(pr, team, nil)
 	mockRepo.On("GetUserById", mock.Anything, reassignReviewer.ID).Return(reassignReviewer, nil)
 	mockRepo.On("SavePR", mock.Anything, mock.MatchedBy(func(pr *domain.PullRequest) bool {
-		return pr.Status == domain.Open})).Return(nil)
+		return pr.Status == domain.Open}), mock.Anything).Return(nil)
 	
 	// Act
-	pr, newReviewerID, err := service.PRreassign(context.Background(), prID, reassignReviewer.ID)
+	pr, newReviewerID, err := service.PRreassign(context.Background(), prID, reassignReviewer.ID, "")
 
 	// Assert
 	assert.NoError(t, err)
@@ -333,10 +333,10 @@ func TestPRreassign_ButPRAlreadyMerged(t *testing.T) {
 	mockRepo.On("GetPRAndTeam", mock.Anything, prID).Return(pr, team, nil)
 	mockRepo.On("GetUserById", mock.Anything, reassignReviewer.ID).Return(reassignReviewer, nil)
 	mockRepo.On("SavePR", mock.Anything, mock.MatchedBy(func(pr *domain.PullRequest) bool {
-		return pr.Status == domain.Merged})).Return(nil)
+		return pr.Status == domain.Merged}), mock.Anything).Return(nil)
 	
 	// Act
-	pr, newReviewerID, err := service.PRreassign(context.Background(), prID, reassignReviewer.ID)
+	pr, newReviewerID, err := service.PRreassign(context.Background(), prID, reassignReviewer.ID, "")
 
 	// Assert
 	assert.Error(t, err)
@@ -400,10 +400,10 @@ func TestPRreassign_ButNoCandidate(t *testing.T) {
 	mockRepo.On("GetPRAndTeam", mock.Anything, prID).Return(pr, team, nil)
 	mockRepo.On("GetUserById", mock.Anything, reassignReviewer.ID).Return(reassignReviewer, nil)
 	mockRepo.On("SavePR", mock.Anything, mock.MatchedBy(func(pr *domain.PullRequest) bool {
-		return pr.Status == domain.Open})).Return(nil)
+		return pr.Status == domain.Open}), mock.Anything).Return(nil)
 	
 	// Act
-	pr, newReviewerID, err := service.PRreassign(context.Background(), prID, reassignReviewer.ID)
+	pr, newReviewerID, err := service.PRreassign(context.Background(), prID, reassignReviewer.ID, "")
 
 	// Assert
 	assert.Error(t, err)
@@ -473,10 +473,10 @@ func TestPRreassign_ButReviewerNotAssigned(t *testing.T) {
 	mockRepo.On("GetPRAndTeam", mock.Anything, prID).Return(pr, team, nil)
 	mockRepo.On("GetUserById", mock.Anything, reassignReviewer.ID).Return(reassignReviewer, nil)
 	mockRepo.On("SavePR", mock.Anything, mock.MatchedBy(func(pr *domain.PullRequest) bool {
-		return pr.Status == domain.Open})).Return(nil)
+		return pr.Status == domain.Open}), mock.Anything).Return(nil)
 	
 	// Act
-	pr, newReviewerID, err := service.PRreassign(context.Background(), prID, reassignReviewer.ID)
+	pr, newReviewerID, err := service.PRreassign(context.Background(), prID, reassignReviewer.ID, "")
 
 	// Assert
 	assert.Error(t, err)
@@ -507,10 +507,10 @@ func TestPRreassign_ButPRNotFound(t *testing.T) {
 
 	mockRepo.On("GetPRAndTeam", mock.Anything, prID).Return(nil, nil, nil)
 	mockRepo.On("GetUserById", mock.Anything, reassignReviewer.ID).Return(reassignReviewer, nil)
-	mockRepo.On("SavePR", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("SavePR", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	
 	// Act
-	pr, newReviewerID, err := service.PRreassign(context.Background(), prID, reassignReviewer.ID)
+	pr, newReviewerID, err := service.PRreassign(context.Background(), prID, reassignReviewer.ID, "")
 
 	// Assert
 	assert.Error(t, err)
@@ -574,10 +574,10 @@ func TestPRreassign_ButReviewerNotFound(t *testing.T) {
 	reassignReviewerID := "r-321"
 	mockRepo.On("GetPRAndTeam", mock.Anything, prID).Return(pr, team, nil)
 	mockRepo.On("GetUserById", mock.Anything, mock.Anything).Return(nil, nil)
-	mockRepo.On("SavePR", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("SavePR", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	
 	// Act
-	pr, newReviewerID, err := service.PRreassign(context.Background(), prID, reassignReviewerID)
+	pr, newReviewerID, err := service.PRreassign(context.Background(), prID, reassignReviewerID, "")
 
 	// Assert
 	assert.Error(t, err)
@@ -604,7 +604,7 @@ func TestPRCreate_GetPRByIdError(t *testing.T) {
 	mockRepo.On("GetPRById", mock.Anything, prID).Return(nil, expectedError)
 
 	// Act
-	pr, err := service.PRCreate(context.Background(), prID, title, authorID)
+	pr, err := service.PRCreate(context.Background(), prID, title, authorID, domain.AssignmentOptions{})
 
 	// Assert
 	assert.Error(t, err)
@@ -630,7 +630,7 @@ func TestPRCreate_GetTeamByUserError(t *testing.T) {
 	mockRepo.On("GetTeamByUser", mock.Anything, authorID).Return(nil, expectedError)
 
 	// Act
-	pr, err := service.PRCreate(context.Background(), prID, title, authorID)
+	pr, err := service.PRCreate(context.Background(), prID, title, authorID, domain.AssignmentOptions{})
 
 	// Assert
 	assert.Error(t, err)
@@ -661,10 +661,10 @@ func TestPRCreate_SavePRError(t *testing.T) {
 
 	mockRepo.On("GetPRById", mock.Anything, prID).Return(nil, nil)
 	mockRepo.On("GetTeamByUser", mock.Anything, authorID).Return(team, nil)
-	mockRepo.On("SavePR", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).Return(expectedError)
+	mockRepo.On("SavePR", mock.Anything, mock.AnythingOfType("*domain.PullRequest"), mock.Anything).Return(expectedError)
 
 	// Act
-	pr, err := service.PRCreate(context.Background(), prID, title, authorID)
+	pr, err := service.PRCreate(context.Background(), prID, title, authorID, domain.AssignmentOptions{})
 
 	// Assert
 	assert.Error(t, err)
@@ -715,7 +715,7 @@ func TestPRMerge_SavePRError(t *testing.T) {
 	}
 
 	mockRepo.On("GetPRById", mock.Anything, prID).Return(existingPR, nil)
-	mockRepo.On("SavePR", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).Return(expectedError)
+	mockRepo.On("SavePR", mock.Anything, mock.AnythingOfType("*domain.PullRequest"), mock.Anything).Return(expectedError)
 
 	// Act
 	pr, err := service.PRMerge(context.Background(), prID)
@@ -741,7 +741,7 @@ func TestPRreassign_GetPRAndTeamError(t *testing.T) {
 	mockRepo.On("GetPRAndTeam", mock.Anything, prID).Return(nil, nil, expectedError)
 
 	// Act
-	pr, newReviewerID, err := service.PRreassign(context.Background(), prID, reviewerID)
+	pr, newReviewerID, err := service.PRreassign(context.Background(), prID, reviewerID, "")
 
 	// Assert
 	assert.Error(t, err)
@@ -784,7 +784,7 @@ func TestPRreassign_GetUserByIdError(t *testing.T) {
 	mockRepo.On("GetUserById", mock.Anything, reviewerID).Return(nil, expectedError)
 
 	// Act
-	resultPR, newReviewerID, err := service.PRreassign(context.Background(), prID, reviewerID)
+	resultPR, newReviewerID, err := service.PRreassign(context.Background(), prID, reviewerID, "")
 
 	// Assert
 	assert.Error(t, err)
@@ -834,10 +834,10 @@ func TestPRreassign_SavePRError(t *testing.T) {
 
 	mockRepo.On("GetPRAndTeam", mock.Anything, prID).Return(pr, team, nil)
 	mockRepo.On("GetUserById", mock.Anything, oldReviewer.ID).Return(oldReviewer, nil)
-	mockRepo.On("SavePR", mock.Anything, mock.AnythingOfType("*domain.PullRequest")).Return(expectedError)
+	mockRepo.On("SavePR", mock.Anything, mock.AnythingOfType("*domain.PullRequest"), mock.Anything).Return(expectedError)
 
 	// Act
-	resultPR, newReviewerID, err := service.PRreassign(context.Background(), prID, oldReviewer.ID)
+	resultPR, newReviewerID, err := service.PRreassign(context.Background(), prID, oldReviewer.ID, "")
 
 	// Assert
 	assert.Error(t, err)
@@ -863,7 +863,7 @@ func TestPRCreate_ConnectionError(t *testing.T) {
 	mockRepo.On("GetPRById", mock.Anything, prID).Return(nil, connectionError)
 
 	// Act
-	pr, err := service.PRCreate(context.Background(), prID, title, authorID)
+	pr, err := service.PRCreate(context.Background(), prID, title, authorID, domain.AssignmentOptions{})
 
 	// Assert
 	assert.Error(t, err)
@@ -904,11 +904,1096 @@ func TestPRreassign_ConnectionError(t *testing.T) {
 	mockRepo.On("GetPRAndTeam", mock.Anything, prID).Return(nil, nil, connectionError)
 
 	// Act
-	pr, newReviewerID, err := service.PRreassign(context.Background(), prID, reviewerID)
+	pr, newReviewerID, err := service.PRreassign(context.Background(), prID, reviewerID, "")
 
 	// Assert
 	assert.Error(t, err)
 	assert.Nil(t, pr)
 	assert.Equal(t, "", newReviewerID)
 	assert.Equal(t, connectionError, err)
-}
\ No newline at end of file
+}
+
+func TestPRCreate_InvalidStrategy(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+
+	opts := domain.AssignmentOptions{Strategy: "alphabetical"}
+
+	// Act
+	pr, err := service.PRCreate(context.Background(), "pr-123", "Test PR", "user1", opts)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, pr)
+	assert.Equal(t, domain.ErrInvalidStrategy, err)
+	mockRepo.AssertNotCalled(t, "GetPRById")
+	mockRepo.AssertNotCalled(t, "SavePR")
+}
+
+func TestPRCreate_RoleRequirementUnmet(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+
+	prID := "pr-123"
+	authorID := "user1"
+	team := &domain.Team{
+		Name: "test-team",
+		Members: []*domain.User{
+			{ID: "user1", Name: "Author", TeamName: "test-team", IsActive: true, Role: domain.RoleLead},
+			{ID: "user2", Name: "Reviewer 1", TeamName: "test-team", IsActive: true, Role: domain.RoleJunior},
+			{ID: "user3", Name: "Reviewer 2", TeamName: "test-team", IsActive: true, Role: domain.RoleMiddle},
+		},
+		RoleRequirements: []domain.RoleRequirement{{MinRole: domain.RoleSenior, Count: 1}},
+	}
+
+	mockRepo.On("GetPRById", mock.Anything, prID).Return(nil, nil)
+	mockRepo.On("GetTeamByUser", mock.Anything, authorID).Return(team, nil)
+	mockRepo.On("SavePR", mock.Anything, mock.Anything, mock.MatchedBy(func(events []domain.ReviewerEvent) bool {
+		return len(events) == 1
+	})).Return(nil)
+
+	// Act
+	pr, err := service.PRCreate(context.Background(), prID, "Test PR", authorID, domain.AssignmentOptions{})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrRoleRequirement)
+	assert.Len(t, pr.ReviewersID, 1)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPRCreate_PairAvoidanceLoadsRecentPairs(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo, WithSeed(1))
+
+	prID := "pr-123"
+	authorID := "user1"
+	team := &domain.Team{
+		Name: "test-team",
+		Members: []*domain.User{
+			{ID: "user1", Name: "Author", TeamName: "test-team", IsActive: true},
+			{ID: "user2", Name: "Reviewer 1", TeamName: "test-team", IsActive: true},
+			{ID: "user3", Name: "Reviewer 2", TeamName: "test-team", IsActive: true},
+		},
+	}
+	opts := domain.AssignmentOptions{Strategy: domain.StrategyPairAvoidance, PairWindowDays: 14}
+
+	mockRepo.On("GetPRById", mock.Anything, prID).Return(nil, nil)
+	mockRepo.On("GetTeamByUser", mock.Anything, authorID).Return(team, nil)
+	mockRepo.On("GetRecentReviewCounts", mock.Anything, authorID, mock.MatchedBy(func(since time.Time) bool {
+		return time.Since(since) > 13*24*time.Hour && time.Since(since) < 15*24*time.Hour
+	})).Return(map[string]int{"user2": 4}, nil)
+	mockRepo.On("SavePR", mock.Anything, mock.MatchedBy(func(pr *domain.PullRequest) bool {
+		return len(pr.ReviewersID) == 2 && !pr.CreatedAt.IsZero()
+	}), mock.Anything).Return(nil)
+
+	// Act
+	pr, err := service.PRCreate(context.Background(), prID, "Test PR", authorID, opts)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user3", "user2"}, pr.ReviewersID)
+	mockRepo.AssertExpectations(t)
+}
+
+func manualReviewerFixture() (*domain.PullRequest, *domain.Team) {
+	teamName := "team"
+	team := &domain.Team{
+		Name: teamName,
+		Members: []*domain.User{
+			{ID: "author", Name: "Andrey", TeamName: teamName, IsActive: true},
+			{ID: "reviewer", Name: "Ivan", TeamName: teamName, IsActive: true},
+			{ID: "candidate", Name: "Petr", TeamName: teamName, IsActive: true},
+			{ID: "inactive", Name: "Alice", TeamName: teamName, IsActive: false},
+		},
+	}
+	pr := &domain.PullRequest{
+		ID:          "pr-123",
+		Title:       "Test PR",
+		AuthorID:    "author",
+		ReviewersID: []string{"reviewer"},
+		Status:      domain.Open,
+	}
+	return pr, team
+}
+
+func TestPRAddReviewer_Success(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	pr, team := manualReviewerFixture()
+
+	mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, team, nil)
+	mockRepo.On("GetUserById", mock.Anything, "candidate").Return(team.Members[2], nil)
+	mockRepo.On("SavePR", mock.Anything, pr, mock.MatchedBy(func(events []domain.ReviewerEvent) bool {
+		return len(events) == 1 &&
+			events[0].PullRequestID == pr.ID &&
+			events[0].UserID == "candidate" &&
+			events[0].Action == domain.ReviewerAdded
+	})).Return(nil)
+
+	// Act
+	result, err := service.PRAddReviewer(context.Background(), pr.ID, "candidate")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"reviewer", "candidate"}, result.ReviewersID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPRAddReviewer_Rejected(t *testing.T) {
+	otherTeamUser := &domain.User{ID: "stranger", Name: "Olga", TeamName: "other", IsActive: true}
+
+	testCases := []struct {
+		name       string
+		reviewerID string
+		prepare    func(pr *domain.PullRequest, team *domain.Team) *domain.User
+		expected   error
+	}{
+		{"Inactive", "inactive", func(pr *domain.PullRequest, team *domain.Team) *domain.User {
+			return team.Members[3]
+		}, domain.ErrReviewerInactive},
+		{"Author", "author", func(pr *domain.PullRequest, team *domain.Team) *domain.User {
+			return team.Members[0]
+		}, domain.ErrReviewerIsAuthor},
+		{"Already assigned", "reviewer", func(pr *domain.PullRequest, team *domain.Team) *domain.User {
+			return team.Members[1]
+		}, domain.ErrAlreadyAssigned},
+		{"Not in team", "stranger", func(pr *domain.PullRequest, team *domain.Team) *domain.User {
+			return otherTeamUser
+		}, domain.ErrNotTeamMember},
+		{"Reviewer limit", "candidate", func(pr *domain.PullRequest, team *domain.Team) *domain.User {
+			pr.ReviewersID = []string{"reviewer", "inactive"}
+			return team.Members[2]
+		}, domain.ErrReviewerLimit},
+		{"Merged", "candidate", func(pr *domain.PullRequest, team *domain.Team) *domain.User {
+			pr.Status = domain.Merged
+			return team.Members[2]
+		}, domain.ErrPRMerged},
+		{"Declined", "candidate", func(pr *domain.PullRequest, team *domain.Team) *domain.User {
+			pr.DeclinedIDs = []string{"candidate"}
+			return team.Members[2]
+		}, domain.ErrReviewerDeclined},
+		{"Author team slot taken on cross-team PR", "candidate", func(pr *domain.PullRequest, team *domain.Team) *domain.User {
+			pr.ExtraTeams = []string{"design"}
+			pr.ReviewerTeams = map[string]string{"reviewer": team.Name}
+			return team.Members[2]
+		}, domain.ErrReviewerLimit},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := &mocks.MockRepository{}
+
+			service := NewService(mockRepo)
+			pr, team := manualReviewerFixture()
+			reviewer := tc.prepare(pr, team)
+
+			mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, team, nil)
+			mockRepo.On("GetUserById", mock.Anything, tc.reviewerID).Return(reviewer, nil)
+
+			// Act
+			result, err := service.PRAddReviewer(context.Background(), pr.ID, tc.reviewerID)
+
+			// Assert
+			assert.Nil(t, result)
+			assert.Equal(t, tc.expected, err)
+			mockRepo.AssertNotCalled(t, "SavePR")
+		})
+	}
+}
+
+func TestPRAddReviewer_CrossTeamPRWithEmptyAuthorSlot(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	pr, team := manualReviewerFixture()
+	pr.ReviewersID = []string{"design1"}
+	pr.ReviewerTeams = map[string]string{"design1": "design"}
+	pr.ExtraTeams = []string{"design"}
+
+	mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, team, nil)
+	mockRepo.On("GetUserById", mock.Anything, "candidate").Return(team.Members[2], nil)
+	mockRepo.On("SavePR", mock.Anything, pr, mock.Anything).Return(nil)
+
+	// Act
+	result, err := service.PRAddReviewer(context.Background(), pr.ID, "candidate")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"design1", "candidate"}, result.ReviewersID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPRAddReviewer_UserNotFound(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	pr, team := manualReviewerFixture()
+
+	mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, team, nil)
+	mockRepo.On("GetUserById", mock.Anything, "ghost").Return(nil, nil)
+
+	// Act
+	result, err := service.PRAddReviewer(context.Background(), pr.ID, "ghost")
+
+	// Assert
+	assert.Nil(t, result)
+	assert.Equal(t, domain.ErrNotFound, err)
+	mockRepo.AssertNotCalled(t, "SavePR")
+}
+
+func TestPRRemoveReviewer_Success(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	pr, _ := manualReviewerFixture()
+
+	mockRepo.On("GetPRById", mock.Anything, pr.ID).Return(pr, nil)
+	mockRepo.On("SavePR", mock.Anything, pr, mock.MatchedBy(func(events []domain.ReviewerEvent) bool {
+		return len(events) == 1 &&
+			events[0].UserID == "reviewer" &&
+			events[0].Action == domain.ReviewerRemoved
+	})).Return(nil)
+
+	// Act
+	result, err := service.PRRemoveReviewer(context.Background(), pr.ID, "reviewer")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, result.ReviewersID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPRRemoveReviewer_NotAssigned(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	pr, _ := manualReviewerFixture()
+
+	mockRepo.On("GetPRById", mock.Anything, pr.ID).Return(pr, nil)
+
+	// Act
+	result, err := service.PRRemoveReviewer(context.Background(), pr.ID, "candidate")
+
+	// Assert
+	assert.Nil(t, result)
+	assert.Equal(t, domain.ErrNotAssigned, err)
+	mockRepo.AssertNotCalled(t, "SavePR")
+}
+
+func TestPRRemoveReviewer_Merged(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	pr, _ := manualReviewerFixture()
+	pr.Status = domain.Merged
+
+	mockRepo.On("GetPRById", mock.Anything, pr.ID).Return(pr, nil)
+
+	// Act
+	result, err := service.PRRemoveReviewer(context.Background(), pr.ID, "reviewer")
+
+	// Assert
+	assert.Nil(t, result)
+	assert.Equal(t, domain.ErrPRMerged, err)
+	mockRepo.AssertNotCalled(t, "SavePR")
+}
+
+func TestPRreassign_RecordsEvents(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	pr, team := manualReviewerFixture()
+
+	mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, team, nil)
+	mockRepo.On("GetUserById", mock.Anything, "reviewer").Return(team.Members[1], nil)
+	mockRepo.On("SavePR", mock.Anything, pr, mock.MatchedBy(func(events []domain.ReviewerEvent) bool {
+		return len(events) == 2 &&
+			events[0].UserID == "reviewer" && events[0].Action == domain.ReviewerReplaced &&
+			events[1].UserID == "candidate" && events[1].Action == domain.ReviewerAssigned &&
+			events[0].CreatedAt.Equal(events[1].CreatedAt)
+	})).Return(nil)
+
+	// Act
+	result, newReviewerID, err := service.PRreassign(context.Background(), pr.ID, "reviewer", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "candidate", newReviewerID)
+	assert.Equal(t, []string{"candidate"}, result.ReviewersID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPRCreate_RecordsAssignedEvents(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	_, team := manualReviewerFixture()
+
+	mockRepo.On("GetPRById", mock.Anything, "pr-1").Return(nil, nil)
+	mockRepo.On("GetTeamByUser", mock.Anything, "author").Return(team, nil)
+	mockRepo.On("SavePR", mock.Anything, mock.Anything, mock.MatchedBy(func(events []domain.ReviewerEvent) bool {
+		if len(events) != 2 {
+			return false
+		}
+		for _, event := range events {
+			if event.PullRequestID != "pr-1" || event.Action != domain.ReviewerAssigned {
+				return false
+			}
+		}
+		return true
+	})).Return(nil)
+
+	// Act
+	pr, err := service.PRCreate(context.Background(), "pr-1", "Test PR", "author", domain.AssignmentOptions{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"reviewer", "candidate"}, pr.ReviewersID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPRreassign_ToRequestedReviewer(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	pr, team := manualReviewerFixture()
+
+	mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, team, nil)
+	mockRepo.On("GetUserById", mock.Anything, "reviewer").Return(team.Members[1], nil)
+	mockRepo.On("GetUserById", mock.Anything, "candidate").Return(team.Members[2], nil)
+	mockRepo.On("SavePR", mock.Anything, pr, mock.Anything).Return(nil)
+
+	// Act
+	result, newReviewerID, err := service.PRreassign(context.Background(), pr.ID, "reviewer", "candidate")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "candidate", newReviewerID)
+	assert.Equal(t, []string{"candidate"}, result.ReviewersID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPRreassign_RequestedReviewerRejected(t *testing.T) {
+	otherTeamUser := &domain.User{ID: "stranger", Name: "Olga", TeamName: "other", IsActive: true}
+
+	testCases := []struct {
+		name          string
+		newReviewerID string
+		newReviewer   func(team *domain.Team) *domain.User
+		expected      error
+	}{
+		{"Inactive", "inactive", func(team *domain.Team) *domain.User { return team.Members[3] }, domain.ErrReviewerInactive},
+		{"Author", "author", func(team *domain.Team) *domain.User { return team.Members[0] }, domain.ErrReviewerIsAuthor},
+		{"Already assigned", "reviewer", func(team *domain.Team) *domain.User { return team.Members[1] }, domain.ErrAlreadyAssigned},
+		{"Not in team", "stranger", func(team *domain.Team) *domain.User { return otherTeamUser }, domain.ErrNotTeamMember},
+		{"Not found", "ghost", func(team *domain.Team) *domain.User { return nil }, domain.ErrNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := &mocks.MockRepository{}
+
+			service := NewService(mockRepo)
+			pr, team := manualReviewerFixture()
+
+			mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, team, nil)
+			mockRepo.On("GetUserById", mock.Anything, "reviewer").Return(team.Members[1], nil)
+			if tc.newReviewerID != "reviewer" {
+				mockRepo.On("GetUserById", mock.Anything, tc.newReviewerID).Return(tc.newReviewer(team), nil)
+			}
+
+			// Act
+			result, newReviewerID, err := service.PRreassign(context.Background(), pr.ID, "reviewer", tc.newReviewerID)
+
+			// Assert
+			assert.Nil(t, result)
+			assert.Empty(t, newReviewerID)
+			assert.Equal(t, tc.expected, err)
+			mockRepo.AssertNotCalled(t, "SavePR")
+		})
+	}
+}
+
+func TestPRreassign_RequestedReviewerDeclined(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	pr, team := manualReviewerFixture()
+	pr.DeclinedIDs = []string{"candidate"}
+
+	mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, team, nil)
+	mockRepo.On("GetUserById", mock.Anything, "reviewer").Return(team.Members[1], nil)
+	mockRepo.On("GetUserById", mock.Anything, "candidate").Return(team.Members[2], nil)
+
+	// Act
+	result, newReviewerID, err := service.PRreassign(context.Background(), pr.ID, "reviewer", "candidate")
+
+	// Assert
+	assert.Nil(t, result)
+	assert.Empty(t, newReviewerID)
+	assert.Equal(t, domain.ErrReviewerDeclined, err)
+	mockRepo.AssertNotCalled(t, "SavePR")
+}
+
+func TestPRreassign_RequestedReviewerBreaksRoleRequirement(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	pr, team := manualReviewerFixture()
+	team.RoleRequirements = []domain.RoleRequirement{{MinRole: domain.RoleSenior, Count: 1}}
+	team.Members[1].Role = domain.RoleSenior
+	team.Members[2].Role = domain.RoleJunior
+
+	mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, team, nil)
+	mockRepo.On("GetUserById", mock.Anything, "reviewer").Return(team.Members[1], nil)
+	mockRepo.On("GetUserById", mock.Anything, "candidate").Return(team.Members[2], nil)
+
+	// Act
+	result, newReviewerID, err := service.PRreassign(context.Background(), pr.ID, "reviewer", "candidate")
+
+	// Assert
+	assert.Nil(t, result)
+	assert.Empty(t, newReviewerID)
+	assert.ErrorIs(t, err, domain.ErrRoleRequirement)
+	assert.Equal(t, []string{"reviewer"}, pr.ReviewersID)
+	mockRepo.AssertNotCalled(t, "SavePR")
+}
+
+func TestPRreassign_RequestedReviewerKeepsRoleRequirement(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	pr, team := manualReviewerFixture()
+	team.RoleRequirements = []domain.RoleRequirement{{MinRole: domain.RoleSenior, Count: 1}}
+	team.Members[1].Role = domain.RoleSenior
+	team.Members[2].Role = domain.RoleLead
+
+	mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, team, nil)
+	mockRepo.On("GetUserById", mock.Anything, "reviewer").Return(team.Members[1], nil)
+	mockRepo.On("GetUserById", mock.Anything, "candidate").Return(team.Members[2], nil)
+	mockRepo.On("SavePR", mock.Anything, pr, mock.Anything).Return(nil)
+
+	// Act
+	result, newReviewerID, err := service.PRreassign(context.Background(), pr.ID, "reviewer", "candidate")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "candidate", newReviewerID)
+	assert.Equal(t, []string{"candidate"}, result.ReviewersID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPRreassign_RequestedReviewerOnMergedPR(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	pr, team := manualReviewerFixture()
+	pr.Status = domain.Merged
+
+	mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, team, nil)
+	mockRepo.On("GetUserById", mock.Anything, "reviewer").Return(team.Members[1], nil)
+
+	// Act
+	result, newReviewerID, err := service.PRreassign(context.Background(), pr.ID, "reviewer", "candidate")
+
+	// Assert
+	assert.Nil(t, result)
+	assert.Empty(t, newReviewerID)
+	assert.Equal(t, domain.ErrPRMerged, err)
+	mockRepo.AssertNotCalled(t, "SavePR")
+}
+
+func TestPRDecline_ReplacesReviewer(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	pr, team := manualReviewerFixture()
+
+	mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, team, nil)
+	mockRepo.On("SavePR", mock.Anything, pr, mock.MatchedBy(func(events []domain.ReviewerEvent) bool {
+		return len(events) == 2 &&
+			events[0].UserID == "reviewer" && events[0].Action == domain.ReviewerDeclined &&
+			events[0].Reason == "too busy" &&
+			events[1].UserID == "candidate" && events[1].Action == domain.ReviewerAssigned
+	})).Return(nil)
+
+	// Act
+	result, newReviewerID, err := service.PRDecline(context.Background(), pr.ID, "reviewer", " too busy ")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "candidate", newReviewerID)
+	assert.Equal(t, []string{"candidate"}, result.ReviewersID)
+	assert.Equal(t, []string{"reviewer"}, result.DeclinedIDs)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPRDecline_NoCandidateRemovesReviewer(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	pr, team := manualReviewerFixture()
+	pr.DeclinedIDs = []string{"candidate"}
+
+	mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, team, nil)
+	mockRepo.On("SavePR", mock.Anything, pr, mock.MatchedBy(func(events []domain.ReviewerEvent) bool {
+		return len(events) == 1 && events[0].Action == domain.ReviewerDeclined
+	})).Return(nil)
+
+	// Act
+	result, newReviewerID, err := service.PRDecline(context.Background(), pr.ID, "reviewer", "no context")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, newReviewerID)
+	assert.Empty(t, result.ReviewersID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPRDecline_OnlySeniorDeclines(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	pr, team := manualReviewerFixture()
+	team.RoleRequirements = []domain.RoleRequirement{{MinRole: domain.RoleSenior, Count: 1}}
+	team.Members[1].Role = domain.RoleSenior
+	team.Members[2].Role = domain.RoleJunior
+
+	mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, team, nil)
+	mockRepo.On("SavePR", mock.Anything, pr, mock.MatchedBy(func(events []domain.ReviewerEvent) bool {
+		return len(events) == 2 &&
+			events[0].UserID == "reviewer" && events[0].Action == domain.ReviewerDeclined &&
+			events[1].UserID == "candidate" && events[1].Action == domain.ReviewerAssigned
+	})).Return(nil)
+
+	// Act
+	result, newReviewerID, err := service.PRDecline(context.Background(), pr.ID, "reviewer", "conflict of interest")
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrRoleRequirement)
+	assert.Equal(t, "candidate", newReviewerID)
+	assert.Equal(t, []string{"candidate"}, result.ReviewersID)
+	assert.Equal(t, []string{"reviewer"}, result.DeclinedIDs)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPRDecline_Rejected(t *testing.T) {
+	testCases := []struct {
+		name       string
+		reviewerID string
+		reason     string
+		prepare    func(pr *domain.PullRequest)
+		expected   error
+	}{
+		{"Empty reason", "reviewer", "", func(pr *domain.PullRequest) {}, domain.ErrInvalidReason},
+		{"Not assigned", "candidate", "too busy", func(pr *domain.PullRequest) {}, domain.ErrNotAssigned},
+		{"Merged", "reviewer", "too busy", func(pr *domain.PullRequest) { pr.Status = domain.Merged }, domain.ErrPRMerged},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := &mocks.MockRepository{}
+
+			service := NewService(mockRepo)
+			pr, team := manualReviewerFixture()
+			tc.prepare(pr)
+
+			mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, team, nil)
+
+			// Act
+			result, newReviewerID, err := service.PRDecline(context.Background(), pr.ID, tc.reviewerID, tc.reason)
+
+			// Assert
+			assert.Nil(t, result)
+			assert.Empty(t, newReviewerID)
+			assert.Equal(t, tc.expected, err)
+			mockRepo.AssertNotCalled(t, "SavePR")
+		})
+	}
+}
+
+func TestPRreassign_SkipsDecliners(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	pr, team := manualReviewerFixture()
+	pr.DeclinedIDs = []string{"candidate"}
+
+	mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, team, nil)
+	mockRepo.On("GetUserById", mock.Anything, "reviewer").Return(team.Members[1], nil)
+
+	// Act
+	result, newReviewerID, err := service.PRreassign(context.Background(), pr.ID, "reviewer", "")
+
+	// Assert
+	assert.Nil(t, result)
+	assert.Empty(t, newReviewerID)
+	assert.Equal(t, domain.ErrNoCandidate, err)
+	mockRepo.AssertNotCalled(t, "SavePR")
+}
+
+func TestPRCreate_ExplainsAssignment(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	_, team := manualReviewerFixture()
+
+	mockRepo.On("GetPRById", mock.Anything, "pr-1").Return(nil, nil)
+	mockRepo.On("GetTeamByUser", mock.Anything, "author").Return(team, nil)
+	mockRepo.On("SavePR", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	// Act
+	pr, err := service.PRCreate(context.Background(), "pr-1", "Test PR", "author", domain.AssignmentOptions{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.AssignmentExplanation{
+		Strategy:   domain.StrategyRandom,
+		Candidates: []string{"reviewer", "candidate"},
+		Excluded: []domain.Exclusion{
+			{UserID: "author", Reason: domain.ExcludedAuthor},
+			{UserID: "inactive", Reason: domain.ExcludedInactive},
+		},
+	}, pr.Explanation)
+}
+
+func TestPRreassign_ExplainsAssignment(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	pr, team := manualReviewerFixture()
+
+	mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, team, nil)
+	mockRepo.On("GetUserById", mock.Anything, "reviewer").Return(team.Members[1], nil)
+	mockRepo.On("SavePR", mock.Anything, pr, mock.Anything).Return(nil)
+
+	// Act
+	result, _, err := service.PRreassign(context.Background(), pr.ID, "reviewer", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"candidate"}, result.Explanation.Candidates)
+	assert.Contains(t, result.Explanation.Excluded, domain.Exclusion{UserID: "reviewer", Reason: domain.ExcludedAlreadyAssigned})
+}
+
+func TestPRCreate_SeededServiceIsReproducible(t *testing.T) {
+	// Arrange
+	newSeededService := func() *Service {
+		mockRepo := &mocks.MockRepository{}
+		mockRepo.On("GetPRById", mock.Anything, "pr-1").Return(nil, nil)
+		mockRepo.On("GetTeamByUser", mock.Anything, "author1").Return(simulationTeam(), nil)
+		mockRepo.On("SavePR", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		return NewService(mockRepo, WithSeed(1))
+	}
+
+	// Act
+	first, firstErr := newSeededService().PRCreate(context.Background(), "pr-1", "Test PR", "author1", domain.AssignmentOptions{})
+	second, secondErr := newSeededService().PRCreate(context.Background(), "pr-1", "Test PR", "author1", domain.AssignmentOptions{})
+
+	// Assert
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.Equal(t, first.ReviewersID, second.ReviewersID)
+}
+
+func TestPRCreate_RoundRobinStartsAfterCursor(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	team := simulationTeam()
+
+	mockRepo.On("GetPRById", mock.Anything, "pr-1").Return(nil, nil)
+	mockRepo.On("GetTeamByUser", mock.Anything, "author1").Return(team, nil)
+	mockRepo.On("AdvanceRoundRobinCursor", mock.Anything, team.Name).Return("user2", nil)
+	mockRepo.On("SavePR", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	// Act
+	pr, err := service.PRCreate(context.Background(), "pr-1", "Test PR", "author1", domain.AssignmentOptions{Strategy: domain.StrategyRoundRobin})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user3", "user1"}, pr.ReviewersID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPRCreate_RoundRobinCursorError(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	team := simulationTeam()
+
+	mockRepo.On("GetPRById", mock.Anything, "pr-1").Return(nil, nil)
+	mockRepo.On("GetTeamByUser", mock.Anything, "author1").Return(team, nil)
+	mockRepo.On("AdvanceRoundRobinCursor", mock.Anything, team.Name).Return("", ErrQueryExecution)
+
+	// Act
+	pr, err := service.PRCreate(context.Background(), "pr-1", "Test PR", "author1", domain.AssignmentOptions{Strategy: domain.StrategyRoundRobin})
+
+	// Assert
+	assert.Nil(t, pr)
+	assert.Equal(t, ErrQueryExecution, err)
+	mockRepo.AssertNotCalled(t, "SavePR")
+}
+
+func TestPRCreate_RoundRobinSavePRError(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	team := simulationTeam()
+
+	mockRepo.On("GetPRById", mock.Anything, "pr-1").Return(nil, nil)
+	mockRepo.On("GetTeamByUser", mock.Anything, "author1").Return(team, nil)
+	mockRepo.On("AdvanceRoundRobinCursor", mock.Anything, team.Name).Return("user2", nil)
+	mockRepo.On("SavePR", mock.Anything, mock.Anything, mock.Anything).Return(ErrQueryExecution)
+
+	// Act
+	pr, err := service.PRCreate(context.Background(), "pr-1", "Test PR", "author1", domain.AssignmentOptions{Strategy: domain.StrategyRoundRobin})
+
+	// Assert
+	assert.Nil(t, pr)
+	assert.Equal(t, ErrQueryExecution, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func crossTeam() *domain.Team {
+	return &domain.Team{
+		Name: "design",
+		Members: []*domain.User{
+			{ID: "design1", TeamName: "design", IsActive: true},
+			{ID: "design2", TeamName: "design", IsActive: true},
+		},
+	}
+}
+
+func TestPRCreate_ExtraTeamsAddReviewerPerTeam(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+
+	mockRepo.On("GetPRById", mock.Anything, "pr-1").Return(nil, nil)
+	mockRepo.On("GetTeamByUser", mock.Anything, "author1").Return(simulationTeam(), nil)
+	mockRepo.On("GetTeamsByNames", mock.Anything, []string{"design"}).Return([]*domain.Team{crossTeam()}, nil)
+	mockRepo.On("SavePR", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	// Act
+	pr, err := service.PRCreate(context.Background(), "pr-1", "Test PR", "author1", domain.AssignmentOptions{ExtraTeams: []string{"design"}})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, pr.ReviewersID, 2)
+	assert.Equal(t, "team1", pr.ReviewerTeams[pr.ReviewersID[0]])
+	assert.Equal(t, "design", pr.ReviewerTeams[pr.ReviewersID[1]])
+	assert.Contains(t, []string{"design1", "design2"}, pr.ReviewersID[1])
+	assert.Equal(t, []string{"design"}, pr.ExtraTeams)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPRCreate_InvalidExtraTeams(t *testing.T) {
+	testCases := []struct {
+		name       string
+		extraTeams []string
+	}{
+		{"author team", []string{"team1"}},
+		{"duplicate", []string{"design", "design"}},
+		{"empty name", []string{""}},
+		{"too many", []string{"a", "b", "c", "d"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := &mocks.MockRepository{}
+
+			service := NewService(mockRepo)
+
+			mockRepo.On("GetPRById", mock.Anything, "pr-1").Return(nil, nil)
+			mockRepo.On("GetTeamByUser", mock.Anything, "author1").Return(simulationTeam(), nil)
+
+			// Act
+			pr, err := service.PRCreate(context.Background(), "pr-1", "Test PR", "author1", domain.AssignmentOptions{ExtraTeams: tc.extraTeams})
+
+			// Assert
+			assert.Nil(t, pr)
+			assert.ErrorIs(t, err, domain.ErrInvalidExtraTeams)
+			mockRepo.AssertNotCalled(t, "GetTeamsByNames")
+			mockRepo.AssertNotCalled(t, "SavePR")
+		})
+	}
+}
+
+func TestPRCreate_ExtraTeamsLeaveNoRoomForRoleRequirements(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	team := simulationTeam()
+	team.RoleRequirements = []domain.RoleRequirement{{MinRole: domain.RoleSenior, Count: 2}}
+
+	mockRepo.On("GetPRById", mock.Anything, "pr-1").Return(nil, nil)
+	mockRepo.On("GetTeamByUser", mock.Anything, "author1").Return(team, nil)
+
+	// Act
+	pr, err := service.PRCreate(context.Background(), "pr-1", "Test PR", "author1", domain.AssignmentOptions{ExtraTeams: []string{"design"}})
+
+	// Assert
+	assert.Nil(t, pr)
+	assert.ErrorIs(t, err, domain.ErrInvalidExtraTeams)
+	mockRepo.AssertNotCalled(t, "GetTeamsByNames")
+	mockRepo.AssertNotCalled(t, "SavePR")
+}
+
+func TestPRCreate_ExtraTeamNotFound(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+
+	mockRepo.On("GetPRById", mock.Anything, "pr-1").Return(nil, nil)
+	mockRepo.On("GetTeamByUser", mock.Anything, "author1").Return(simulationTeam(), nil)
+	mockRepo.On("GetTeamsByNames", mock.Anything, []string{"design", "missing"}).Return([]*domain.Team{crossTeam()}, nil)
+
+	// Act
+	pr, err := service.PRCreate(context.Background(), "pr-1", "Test PR", "author1", domain.AssignmentOptions{ExtraTeams: []string{"design", "missing"}})
+
+	// Assert
+	assert.Nil(t, pr)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	mockRepo.AssertNotCalled(t, "SavePR")
+}
+
+func TestPRreassign_CrossTeamReviewerReplacedFromOwnTeam(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	design := crossTeam()
+	pr := &domain.PullRequest{
+		ID:            "pr-1",
+		AuthorID:      "author1",
+		Status:        domain.Open,
+		ReviewersID:   []string{"user1", "design1"},
+		ReviewerTeams: map[string]string{"user1": "team1", "design1": "design"},
+	}
+
+	mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, simulationTeam(), nil)
+	mockRepo.On("GetUserById", mock.Anything, "design1").Return(design.Members[0], nil)
+	mockRepo.On("GetTeamByName", mock.Anything, "design").Return(design, nil)
+	mockRepo.On("SavePR", mock.Anything, pr, mock.Anything).Return(nil)
+
+	// Act
+	result, newReviewerID, err := service.PRreassign(context.Background(), pr.ID, "design1", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "design2", newReviewerID)
+	assert.Equal(t, []string{"user1", "design2"}, result.ReviewersID)
+	assert.Equal(t, map[string]string{"user1": "team1", "design2": "design"}, result.ReviewerTeams)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPRCreate_StoresPaths(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	paths := []string{"api/handler.go", "web/page.tsx"}
+
+	mockRepo.On("GetPRById", mock.Anything, "pr-1").Return(nil, nil)
+	mockRepo.On("GetTeamByUser", mock.Anything, "author1").Return(simulationTeam(), nil)
+	mockRepo.On("SavePR", mock.Anything, mock.MatchedBy(func(pr *domain.PullRequest) bool {
+		return assert.ObjectsAreEqual(paths, pr.Paths)
+	}), mock.Anything).Return(nil)
+
+	// Act
+	_, err := service.PRCreate(context.Background(), "pr-1", "Test PR", "author1", domain.AssignmentOptions{Paths: paths})
+
+	// Assert
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPRreassign_PrefersOwnerOfStoredPaths(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	team := simulationTeam()
+	team.Members = append(team.Members, &domain.User{ID: "user5", TeamName: "team1", IsActive: true})
+	team.OwnerRules = []domain.OwnerRule{{Pattern: "/api/", UserIDs: []string{"user1", "user3"}}}
+	pr := &domain.PullRequest{
+		ID:          "pr-1",
+		AuthorID:    "author1",
+		Status:      domain.Open,
+		ReviewersID: []string{"user1", "user2"},
+		Paths:       []string{"api/handler.go"},
+	}
+
+	mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, team, nil)
+	mockRepo.On("GetUserById", mock.Anything, "user1").Return(team.Members[1], nil)
+	mockRepo.On("SavePR", mock.Anything, pr, mock.Anything).Return(nil)
+
+	// Act
+	result, newReviewerID, err := service.PRreassign(context.Background(), pr.ID, "user1", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "user3", newReviewerID)
+	assert.Equal(t, []string{"user3", "user2"}, result.ReviewersID)
+	assert.Contains(t, result.Explanation.Excluded, domain.Exclusion{UserID: "user5", Reason: domain.ExcludedNotOwner})
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPRReview_RecordsDecision(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	pr := shortHandedPR("pr-1", "user1")
+
+	mockRepo.On("GetPRById", mock.Anything, pr.ID).Return(pr, nil)
+	mockRepo.On("SavePR", mock.Anything, pr, mock.MatchedBy(func(events []domain.ReviewerEvent) bool {
+		return len(events) == 1 && events[0].UserID == "user1" && events[0].Action == domain.ReviewerApproved
+	})).Return(nil)
+
+	// Act
+	result, err := service.PRReview(context.Background(), pr.ID, "user1", domain.ReviewerApproved)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user1"}, result.ReviewersID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPRReview_Rejected(t *testing.T) {
+	testCases := []struct {
+		name     string
+		status   domain.PRStatus
+		reviewer string
+		decision domain.ReviewerAction
+		wantErr  error
+	}{
+		{"unknown decision", domain.Open, "user1", domain.ReviewerDeclined, domain.ErrInvalidDecision},
+		{"not assigned", domain.Open, "user2", domain.ReviewerChangesRequested, domain.ErrNotAssigned},
+		{"merged", domain.Merged, "user1", domain.ReviewerApproved, domain.ErrPRMerged},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := &mocks.MockRepository{}
+
+			service := NewService(mockRepo)
+			pr := shortHandedPR("pr-1", "user1")
+			pr.Status = tc.status
+
+			mockRepo.On("GetPRById", mock.Anything, pr.ID).Return(pr, nil)
+
+			// Act
+			result, err := service.PRReview(context.Background(), pr.ID, tc.reviewer, tc.decision)
+
+			// Assert
+			assert.Nil(t, result)
+			assert.Equal(t, tc.wantErr, err)
+			mockRepo.AssertNotCalled(t, "SavePR")
+		})
+	}
+}
+
+func TestPRList_Success(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	status := domain.Open
+	filter := domain.PRFilter{Status: &status, TeamName: "team1", Limit: 10}
+	prs := []*domain.PullRequest{shortHandedPR("pr-2", "user1"), shortHandedPR("pr-1")}
+
+	mockRepo.On("ListPRs", mock.Anything, filter).Return(prs, nil)
+
+	// Act
+	result, err := service.PRList(context.Background(), filter)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, prs, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPRList_EmptyPage(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	filter := domain.PRFilter{Limit: 10, Offset: 100}
+
+	mockRepo.On("ListPRs", mock.Anything, filter).Return(nil, nil)
+
+	// Act
+	result, err := service.PRList(context.Background(), filter)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Empty(t, result)
+}
+
+func TestPRList_InvalidPage(t *testing.T) {
+	testCases := []struct {
+		name   string
+		filter domain.PRFilter
+	}{
+		{"zero limit", domain.PRFilter{Limit: 0}},
+		{"limit too large", domain.PRFilter{Limit: 1001}},
+		{"negative offset", domain.PRFilter{Limit: 10, Offset: -1}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := &mocks.MockRepository{}
+
+			service := NewService(mockRepo)
+
+			// Act
+			result, err := service.PRList(context.Background(), tc.filter)
+
+			// Assert
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, domain.ErrInvalidPRFilter)
+			mockRepo.AssertNotCalled(t, "ListPRs")
+		})
+	}
+}
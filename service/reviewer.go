@@ -3,39 +3,404 @@ package service
 import (
 	"math/rand"
 	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/J0hnLenin/ReviewRequest/domain"
 )
 
+const (
+	minutesPerDay     = 24 * 60
+	defaultPairWindow = 30
+	maxDeclineReason  = 255
+)
+
+type assignment struct {
+	team *domain.Team
+	opts domain.AssignmentOptions
+	now  time.Time
+	// recentReviews counts how often each user reviewed the author within the pair window.
+	recentReviews map[string]int
+	// rnd is the random source for picks; nil means the global math/rand source.
+	rnd *rand.Rand
+	// cursor is the member picked last by the round-robin strategy.
+	cursor string
+	// extraTeams each provide one reviewer in addition to a single one from
+	// team; the PR lists their names in ExtraTeams.
+	extraTeams []*domain.Team
+	// outcomes records how each member fared in the picks so far: "" once they
+	// were in a candidate pool, otherwise why the last pick left them out.
+	// Cross-team picks share the map with the pick that started them.
+	outcomes map[string]domain.ExclusionReason
+}
+
+// acceptFunc vets a member who passed the basic checks for a particular
+// pick, returning why they are left out or "" to keep them.
+type acceptFunc func(*domain.User) domain.ExclusionReason
+
+func anyone(*domain.User) domain.ExclusionReason { return "" }
+
+func newAssignment(t *domain.Team, opts domain.AssignmentOptions, now time.Time) *assignment {
+	return &assignment{
+		team:     t,
+		opts:     opts,
+		now:      now,
+		outcomes: make(map[string]domain.ExclusionReason),
+	}
+}
+
+// assignment starts a pick that draws from the service's random source.
+func (s *Service) assignment(t *domain.Team, opts domain.AssignmentOptions, now time.Time) *assignment {
+	a := newAssignment(t, opts, now)
+	a.rnd = s.rnd
+	return a
+}
+
+func validStrategy(s domain.AssignmentStrategy) bool {
+	switch s {
+	case "", domain.StrategyRandom, domain.StrategyWorkingHours, domain.StrategyPairAvoidance,
+		domain.StrategyWeighted, domain.StrategyRoundRobin:
+		return true
+	}
+	return false
+}
+
+func validWorkingHours(wh domain.WorkingHours) bool {
+	if wh.TimeZone == "" {
+		return true
+	}
+	if _, err := time.LoadLocation(wh.TimeZone); err != nil {
+		return false
+	}
+	return wh.Start >= 0 && wh.Start < minutesPerDay &&
+		wh.End >= 0 && wh.End < minutesPerDay &&
+		wh.Start != wh.End
+}
+
+func inWindow(minute int, start int, end int) bool {
+	if start <= end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+// availableWithin reports whether the user is working at now or starts
+// within the given duration. Users without configured hours are always available.
+func availableWithin(u *domain.User, now time.Time, ahead time.Duration) bool {
+	wh := u.WorkingHours
+	if wh.TimeZone == "" {
+		return true
+	}
+	loc, err := time.LoadLocation(wh.TimeZone)
+	if err != nil {
+		return true
+	}
+	local := now.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	if inWindow(minute, wh.Start, wh.End) {
+		return true
+	}
+	untilStart := (wh.Start - minute + minutesPerDay) % minutesPerDay
+	return time.Duration(untilStart)*time.Minute <= ahead
+}
+
 func prContainsReviewer(pr *domain.PullRequest, userID string) bool {
 	return slices.Contains(pr.ReviewersID,userID)
 }
 
+func candidateError(pr *domain.PullRequest, u *domain.User) error {
+	switch {
+	case pr.AuthorID == u.ID:
+		return domain.ErrReviewerIsAuthor
+	case prContainsReviewer(pr, u.ID):
+		return domain.ErrAlreadyAssigned
+	case !u.IsActive:
+		return domain.ErrReviewerInactive
+	}
+	return nil
+}
+
+// requestedCandidateError validates a reviewer named explicitly by a user
+// rather than picked by the assignment.
+func requestedCandidateError(team *domain.Team, pr *domain.PullRequest, u *domain.User) error {
+	if u.TeamName != team.Name {
+		return domain.ErrNotTeamMember
+	}
+	if err := candidateError(pr, u); err != nil {
+		return err
+	}
+	if declined(pr, u.ID) {
+		return domain.ErrReviewerDeclined
+	}
+	return nil
+}
+
+func reviewWeight(u *domain.User) int {
+	if u.ReviewWeight == nil {
+		return domain.DefaultReviewWeight
+	}
+	return *u.ReviewWeight
+}
+
+func validReviewWeight(weight *int) bool {
+	return weight == nil || (*weight >= 0 && *weight <= domain.MaxReviewWeight)
+}
+
+// autoAssignable reports whether u may be picked automatically; users with
+// zero weight can only be assigned by hand.
+func autoAssignable(u *domain.User) bool {
+	return reviewWeight(u) > 0
+}
+
+func declined(pr *domain.PullRequest, userID string) bool {
+	return slices.Contains(pr.DeclinedIDs, userID)
+}
+
 func validCandidate(pr *domain.PullRequest, u *domain.User) bool {
-	return u.IsActive &&
-		!prContainsReviewer(pr, u.ID) &&
-		pr.AuthorID != u.ID
+	return candidateError(pr, u) == nil
+}
+
+func (a *assignment) preferred(candidates []*domain.User) []*domain.User {
+	if a.opts.Strategy != domain.StrategyWorkingHours {
+		return candidates
+	}
+	ahead := time.Duration(a.opts.WithinHours) * time.Hour
+	available := make([]*domain.User, 0, len(candidates))
+	for _, c := range candidates {
+		if availableWithin(c, a.now, ahead) {
+			available = append(available, c)
+		}
+	}
+	if len(available) == 0 {
+		return candidates
+	}
+	return available
+}
+
+func (a *assignment) member(userID string) *domain.User {
+	for _, member := range a.team.Members {
+		if member.ID == userID {
+			return member
+		}
+	}
+	return nil
+}
+
+func pairWindowStart(opts domain.AssignmentOptions, now time.Time) time.Time {
+	days := opts.PairWindowDays
+	if days <= 0 {
+		days = defaultPairWindow
+	}
+	return now.AddDate(0, 0, -days)
+}
+
+// weight penalizes candidates who recently reviewed the same author when
+// pair avoidance is requested and follows the user's review weight for the
+// weighted strategy; otherwise every candidate is equally likely.
+func (a *assignment) weight(u *domain.User) float64 {
+	switch a.opts.Strategy {
+	case domain.StrategyPairAvoidance:
+		recent := a.recentReviews[u.ID]
+		return 1 / float64((1+recent)*(1+recent))
+	case domain.StrategyWeighted:
+		return float64(reviewWeight(u))
+	}
+	return 1
+}
+
+func (a *assignment) float64() float64 {
+	if a.rnd == nil {
+		return rand.Float64()
+	}
+	return a.rnd.Float64()
+}
+
+func (a *assignment) pickWeighted(candidates []*domain.User) *domain.User {
+	total := 0.0
+	for _, c := range candidates {
+		total += a.weight(c)
+	}
+	point := a.float64() * total
+	for _, c := range candidates {
+		point -= a.weight(c)
+		if point < 0 {
+			return c
+		}
+	}
+	return candidates[len(candidates)-1]
+}
+
+func eligible(pr *domain.PullRequest, u *domain.User) bool {
+	return validCandidate(pr, u) && !declined(pr, u.ID) && autoAssignable(u)
 }
 
-func newReviewer(t *domain.Team, pr *domain.PullRequest) *domain.User {
-	candidates := make([]*domain.User, 0, len(t.Members))
+// hasCandidate reports whether anyone in the team could be picked at all,
+// without recording it as a pick.
+func (a *assignment) hasCandidate(pr *domain.PullRequest) bool {
+	return slices.ContainsFunc(a.team.Members, func(u *domain.User) bool { return eligible(pr, u) })
+}
+
+// record notes why member was left out of a pick, unless an earlier pick
+// already counted them as a candidate.
+func (a *assignment) record(member *domain.User, reason domain.ExclusionReason) {
+	if outcome, ok := a.outcomes[member.ID]; ok && outcome == "" {
+		return
+	}
+	a.outcomes[member.ID] = reason
+}
 
-	for _, member := range t.Members {
-		if validCandidate(pr, member) {
-			candidates = append(candidates, member)
+// atCapacity records the eligible members of team as left out because the
+// team's reviewer slots on pr were already taken.
+func (a *assignment) atCapacity(pr *domain.PullRequest, team *domain.Team) {
+	for _, member := range team.Members {
+		if eligible(pr, member) {
+			a.record(member, domain.ExcludedAtCapacity)
 		}
 	}
+}
+
+func (a *assignment) pickReviewer(pr *domain.PullRequest, accept acceptFunc) *domain.User {
+	candidates := make([]*domain.User, 0, len(a.team.Members))
 
+	for _, member := range a.team.Members {
+		if !eligible(pr, member) {
+			continue
+		}
+		if reason := accept(member); reason != "" {
+			a.record(member, reason)
+			continue
+		}
+		candidates = append(candidates, member)
+	}
+
+	preferred := a.preferred(candidates)
+	for _, c := range candidates {
+		if slices.Contains(preferred, c) {
+			a.record(c, "")
+		} else {
+			a.record(c, domain.ExcludedOffHours)
+		}
+	}
+	candidates = preferred
 	if len(candidates) == 0 {
 		return nil
 	}
 
-	ind := rand.Intn(len(candidates))
-	return candidates[ind]
+	if a.opts.Strategy == domain.StrategyRoundRobin {
+		return a.pickNext(candidates)
+	}
+	return a.pickWeighted(candidates)
+}
+
+// pickNext takes the first candidate after the cursor in id order, wrapping
+// around, so successive picks cycle through the team.
+func (a *assignment) pickNext(candidates []*domain.User) *domain.User {
+	next := slices.MinFunc(candidates, func(x, y *domain.User) int {
+		return strings.Compare(x.ID, y.ID)
+	})
+	for _, c := range candidates {
+		if c.ID > a.cursor && (next.ID <= a.cursor || c.ID < next.ID) {
+			next = c
+		}
+	}
+	a.cursor = next.ID
+	return next
+}
+
+// pickOwnerFirst picks a reviewer accepted by accept, preferring an owner of
+// a changed path that no reviewer but leavingID owns yet.
+func (a *assignment) pickOwnerFirst(pr *domain.PullRequest, leavingID string, accept acceptFunc) *domain.User {
+	for _, rule := range requiredOwners(a.team.OwnerRules, a.opts.Paths) {
+		owned := slices.ContainsFunc(pr.ReviewersID, func(reviewerID string) bool {
+			reviewer := a.member(reviewerID)
+			return reviewerID != leavingID && reviewer != nil && ownsRule(rule, reviewer)
+		})
+		if owned {
+			continue
+		}
+		owner := a.pickReviewer(pr, func(u *domain.User) domain.ExclusionReason {
+			if !ownsRule(rule, u) {
+				return domain.ExcludedNotOwner
+			}
+			return accept(u)
+		})
+		if owner != nil {
+			return owner
+		}
+	}
+	return a.pickReviewer(pr, accept)
+}
+
+func (a *assignment) newReviewer(pr *domain.PullRequest) *domain.User {
+	return a.pickReviewer(pr, anyone)
+}
+
+func (a *assignment) reviewers(reviewerIDs []string) []*domain.User {
+	reviewers := make([]*domain.User, 0, len(reviewerIDs))
+	for _, reviewerID := range reviewerIDs {
+		if reviewer := a.member(reviewerID); reviewer != nil {
+			reviewers = append(reviewers, reviewer)
+		}
+	}
+	return reviewers
+}
+
+// keepsRequirementsReachable reports whether the team's role requirements can
+// still be met after u joins the reviewers, given the slots left afterwards.
+func (a *assignment) keepsRequirementsReachable(pr *domain.PullRequest, u *domain.User) bool {
+	if len(a.team.RoleRequirements) == 0 {
+		return true
+	}
+	reviewers := append(a.reviewers(pr.ReviewersID), u)
+	unmet := unmetRequirements(a.team.RoleRequirements, reviewers)
+	return requiredCount(unmet) <= authorSlots(pr)-len(ownReviewers(pr))-1
 }
 
-func addReviewer(pr *domain.PullRequest, userID string) {
+// reachable accepts the members that keep the role requirements reachable.
+func (a *assignment) reachable(pr *domain.PullRequest) acceptFunc {
+	return func(u *domain.User) domain.ExclusionReason {
+		if !a.keepsRequirementsReachable(pr, u) {
+			return domain.ExcludedRoleRequirement
+		}
+		return ""
+	}
+}
+
+func (a *assignment) ownerAssigned(pr *domain.PullRequest, rule *domain.OwnerRule) bool {
+	for _, reviewerID := range pr.ReviewersID {
+		if reviewer := a.member(reviewerID); reviewer != nil && ownsRule(rule, reviewer) {
+			return true
+		}
+	}
+	return false
+}
+
+func addReviewer(pr *domain.PullRequest, userID string, teamName string) {
 	pr.ReviewersID = append(pr.ReviewersID, userID)
+	if pr.ReviewerTeams == nil {
+		pr.ReviewerTeams = make(map[string]string)
+	}
+	pr.ReviewerTeams[userID] = teamName
+}
+
+func removeReviewer(pr *domain.PullRequest, userID string) error {
+	ind := slices.Index(pr.ReviewersID, userID)
+	if ind == -1 {
+		return domain.ErrNotAssigned
+	}
+	pr.ReviewersID = slices.Delete(pr.ReviewersID, ind, ind+1)
+	delete(pr.ReviewerTeams, userID)
+	return nil
+}
+
+func reviewerEvent(pr *domain.PullRequest, userID string, action domain.ReviewerAction, now time.Time) domain.ReviewerEvent {
+	return domain.ReviewerEvent{
+		PullRequestID: pr.ID,
+		UserID:        userID,
+		Action:        action,
+		CreatedAt:     now,
+	}
 }
 
 func replaceReviewer(pr *domain.PullRequest, oldReviewerID string, newReviewerID string) error {
@@ -44,16 +409,226 @@ func replaceReviewer(pr *domain.PullRequest, oldReviewerID string, newReviewerID
 		return domain.ErrNotAssigned
 	}
 	pr.ReviewersID[ind] = newReviewerID
+	if teamName, ok := pr.ReviewerTeams[oldReviewerID]; ok {
+		delete(pr.ReviewerTeams, oldReviewerID)
+		pr.ReviewerTeams[newReviewerID] = teamName
+	}
 	return nil
 }
 
-func fillReviewers(pr *domain.PullRequest, t *domain.Team) {
-	for len(pr.ReviewersID) < domain.MaxReviewers {
-		reviewer := newReviewer(t, pr)
+func validDeclineReason(reason string) bool {
+	reason = strings.TrimSpace(reason)
+	return reason != "" && utf8.RuneCountInString(reason) <= maxDeclineReason
+}
+
+// explain describes a pick that added picked to the PR; existing holds the
+// reviewers assigned before the pick. Members who were never weighed because
+// the slots filled up first are reported as not picked.
+func (a *assignment) explain(pr *domain.PullRequest, existing []string, picked []string) *domain.AssignmentExplanation {
+	strategy := a.opts.Strategy
+	if strategy == "" {
+		strategy = domain.StrategyRandom
+	}
+	explanation := &domain.AssignmentExplanation{
+		Strategy:   strategy,
+		Candidates: []string{},
+		Excluded:   []domain.Exclusion{},
+	}
+
+	members := slices.Clone(a.team.Members)
+	for _, extra := range a.extraTeams {
+		members = append(members, extra.Members...)
+	}
+
+	for _, member := range members {
+		var reason domain.ExclusionReason
+		switch {
+		case member.ID == pr.AuthorID:
+			reason = domain.ExcludedAuthor
+		case slices.Contains(existing, member.ID):
+			reason = domain.ExcludedAlreadyAssigned
+		case slices.Contains(picked, member.ID):
+		case !member.IsActive:
+			reason = domain.ExcludedInactive
+		case declined(pr, member.ID):
+			reason = domain.ExcludedDeclined
+		case !autoAssignable(member):
+			reason = domain.ExcludedZeroWeight
+		default:
+			outcome, ok := a.outcomes[member.ID]
+			if !ok {
+				outcome = domain.ExcludedNotPicked
+			}
+			reason = outcome
+		}
+		if reason == "" {
+			explanation.Candidates = append(explanation.Candidates, member.ID)
+			continue
+		}
+		explanation.Excluded = append(explanation.Excluded, domain.Exclusion{UserID: member.ID, Reason: reason})
+	}
+	return explanation
+}
+
+// authorSlotsWithExtraTeams is how many reviewers the author's team provides
+// when extra teams are asked for one reviewer each.
+const authorSlotsWithExtraTeams = 1
+
+// authorSlots is how many reviewers the author's team provides on pr.
+func authorSlots(pr *domain.PullRequest) int {
+	if len(pr.ExtraTeams) > 0 {
+		return authorSlotsWithExtraTeams
+	}
+	return domain.MaxReviewers
+}
+
+// reviewerSlots is how many reviewers pr takes from all teams together.
+func reviewerSlots(pr *domain.PullRequest) int {
+	return authorSlots(pr) + len(pr.ExtraTeams)
+}
+
+// ownReviewers returns the reviewers filling the author's team slots, that
+// is everyone not representing one of the extra teams.
+func ownReviewers(pr *domain.PullRequest) []string {
+	own := make([]string, 0, len(pr.ReviewersID))
+	for _, reviewerID := range pr.ReviewersID {
+		if !slices.Contains(pr.ExtraTeams, pr.ReviewerTeams[reviewerID]) {
+			own = append(own, reviewerID)
+		}
+	}
+	return own
+}
+
+// forTeam starts a pick of a single cross-team reviewer from t. Path
+// ownership and the round-robin cursor only apply to the author's team.
+func (a *assignment) forTeam(t *domain.Team) *assignment {
+	opts := a.opts
+	opts.Paths = nil
+	if opts.Strategy == domain.StrategyRoundRobin {
+		opts.Strategy = domain.StrategyRandom
+	}
+	sub := newAssignment(t, opts, a.now)
+	sub.recentReviews = a.recentReviews
+	sub.rnd = a.rnd
+	sub.outcomes = a.outcomes
+	return sub
+}
+
+// prOptions restores the options stored on pr for a later pick from team.
+// Path ownership only applies to the author's team.
+func prOptions(pr *domain.PullRequest, team *domain.Team) domain.AssignmentOptions {
+	if slices.Contains(pr.ExtraTeams, team.Name) {
+		return domain.AssignmentOptions{}
+	}
+	return domain.AssignmentOptions{Paths: pr.Paths}
+}
+
+func represented(pr *domain.PullRequest, teamName string) bool {
+	for _, name := range pr.ReviewerTeams {
+		if name == teamName {
+			return true
+		}
+	}
+	return false
+}
+
+// fillReviewers adds reviewers to the slots of the author's team and of each
+// extra team that are still empty. Candidates who would leave the author's
+// team role requirements unreachable are skipped, so their slots stay empty
+// for a later fill; a requirement error is returned while any requirement is
+// unmet.
+func (a *assignment) fillReviewers(pr *domain.PullRequest) error {
+	if len(ownReviewers(pr)) >= authorSlots(pr) {
+		a.atCapacity(pr, a.team)
+	}
+	for _, rule := range requiredOwners(a.team.OwnerRules, a.opts.Paths) {
+		if len(ownReviewers(pr)) >= authorSlots(pr) {
+			break
+		}
+		if a.ownerAssigned(pr, rule) {
+			continue
+		}
+		owner := a.pickReviewer(pr, func(u *domain.User) domain.ExclusionReason {
+			if !ownsRule(rule, u) {
+				return domain.ExcludedNotOwner
+			}
+			return a.reachable(pr)(u)
+		})
+		if owner != nil {
+			addReviewer(pr, owner.ID, a.team.Name)
+		}
+	}
+
+	for len(ownReviewers(pr)) < authorSlots(pr) {
+		reviewer := a.pickReviewer(pr, a.reachable(pr))
 		if reviewer == nil {
 			break
 		}
-		addReviewer(pr, reviewer.ID)
+		addReviewer(pr, reviewer.ID, a.team.Name)
 	}
+
+	for _, extra := range a.extraTeams {
+		if represented(pr, extra.Name) {
+			a.atCapacity(pr, extra)
+			continue
+		}
+		if reviewer := a.forTeam(extra).newReviewer(pr); reviewer != nil {
+			addReviewer(pr, reviewer.ID, extra.Name)
+		}
+	}
+
+	unmet := unmetRequirements(a.team.RoleRequirements, a.reviewers(pr.ReviewersID))
+	if len(unmet) > 0 {
+		return requirementError(unmet[0])
+	}
+	return nil
+}
+
+// replacementFor picks a reviewer to take over from oldReviewerID without
+// leaving the team's role requirements less satisfied than before. Owners of
+// changed paths that only oldReviewerID covered come first.
+func (a *assignment) replacementFor(pr *domain.PullRequest, oldReviewerID string) (*domain.User, error) {
+	if !a.hasCandidate(pr) {
+		return nil, domain.ErrNoCandidate
+	}
+
+	keepsRoles, unmet := a.rolesWithout(pr, oldReviewerID)
+	replacement := a.pickOwnerFirst(pr, oldReviewerID, keepsRoles)
+	if replacement == nil {
+		return nil, requirementError(unmet[0])
+	}
+	return replacement, nil
 }
 
+// requestedReplacementError checks that u, named by a user to take over from
+// oldReviewerID, keeps the team's role requirements as satisfied as before.
+func (a *assignment) requestedReplacementError(pr *domain.PullRequest, oldReviewerID string, u *domain.User) error {
+	if keepsRoles, unmet := a.rolesWithout(pr, oldReviewerID); keepsRoles(u) != "" {
+		return requirementError(unmet[0])
+	}
+	return nil
+}
+
+// rolesWithout returns a check that a reviewer taking over from oldReviewerID
+// leaves the team's role requirements no less satisfied than before, along
+// with the requirements left unmet once oldReviewerID is gone.
+func (a *assignment) rolesWithout(pr *domain.PullRequest, oldReviewerID string) (acceptFunc, []domain.RoleRequirement) {
+	current := a.reviewers(pr.ReviewersID)
+	unmetBefore := requiredCount(unmetRequirements(a.team.RoleRequirements, current))
+
+	remaining := make([]*domain.User, 0, len(current))
+	for _, reviewer := range current {
+		if reviewer.ID != oldReviewerID {
+			remaining = append(remaining, reviewer)
+		}
+	}
+
+	keepsRoles := func(u *domain.User) domain.ExclusionReason {
+		unmet := unmetRequirements(a.team.RoleRequirements, append(slices.Clip(remaining), u))
+		if requiredCount(unmet) > unmetBefore {
+			return domain.ExcludedRoleRequirement
+		}
+		return ""
+	}
+	return keepsRoles, unmetRequirements(a.team.RoleRequirements, remaining)
+}
@@ -1,7 +1,10 @@
 package service
 
 import (
+	"math/rand"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/J0hnLenin/ReviewRequest/domain"
 	"github.com/stretchr/testify/assert"
@@ -68,7 +71,7 @@ func TestFillReviewers_ZeroReviewers(t *testing.T) {
 		},
 	}
 
-	fillReviewers(pr, team)
+	assert.NoError(t, newAssignment(team, domain.AssignmentOptions{}, time.Now()).fillReviewers(pr))
 
 	assert.Len(t, pr.ReviewersID, 0)
 	assert.NotContains(t, pr.ReviewersID, "author1")
@@ -89,7 +92,7 @@ func TestFillReviewers_OneReviewer(t *testing.T) {
 		},
 	}
 
-	fillReviewers(pr, team)
+	assert.NoError(t, newAssignment(team, domain.AssignmentOptions{}, time.Now()).fillReviewers(pr))
 
 	assert.Len(t, pr.ReviewersID, 1)
 	assert.Contains(t, pr.ReviewersID, "user2")
@@ -113,7 +116,7 @@ func TestFillReviewers_TwoReviewers(t *testing.T) {
 		},
 	}
 
-	fillReviewers(pr, team)
+	assert.NoError(t, newAssignment(team, domain.AssignmentOptions{}, time.Now()).fillReviewers(pr))
 
 	assert.Len(t, pr.ReviewersID, 2)
 	assert.NotContains(t, pr.ReviewersID, "author1")
@@ -138,4 +141,647 @@ func TestReplaceReviewer_NotAssigned(t *testing.T) {
 	err := replaceReviewer(pr, "user1", "user2")
 	assert.Error(t, err)
 	assert.Equal(t, domain.ErrNotAssigned, err)
-}	
\ No newline at end of file
+}	
+
+func TestAvailableWithin(t *testing.T) {
+	// 10:00 UTC is 13:00 in Moscow and 12:00 in Berlin (summer time)
+	now := time.Date(2025, time.July, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		hours    domain.WorkingHours
+		ahead    time.Duration
+		expected bool
+	}{
+		{"No working hours", domain.WorkingHours{}, 0, true},
+		{"Inside window", domain.WorkingHours{TimeZone: "Europe/Moscow", Start: 9 * 60, End: 18 * 60}, 0, true},
+		{"Before window", domain.WorkingHours{TimeZone: "Europe/Berlin", Start: 14 * 60, End: 22 * 60}, 0, false},
+		{"Starts soon enough", domain.WorkingHours{TimeZone: "Europe/Berlin", Start: 14 * 60, End: 22 * 60}, 2 * time.Hour, true},
+		{"After window", domain.WorkingHours{TimeZone: "Asia/Yerevan", Start: 6 * 60, End: 13 * 60}, time.Hour, false},
+		{"Overnight window", domain.WorkingHours{TimeZone: "UTC", Start: 22 * 60, End: 11 * 60}, 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			user := &domain.User{ID: "user1", IsActive: true, WorkingHours: tc.hours}
+			assert.Equal(t, tc.expected, availableWithin(user, now, tc.ahead))
+		})
+	}
+}
+
+func TestValidWorkingHours(t *testing.T) {
+	assert.True(t, validWorkingHours(domain.WorkingHours{}))
+	assert.True(t, validWorkingHours(domain.WorkingHours{TimeZone: "Europe/Moscow", Start: 9 * 60, End: 18 * 60}))
+	assert.False(t, validWorkingHours(domain.WorkingHours{TimeZone: "Mars/Olympus", Start: 9 * 60, End: 18 * 60}))
+	assert.False(t, validWorkingHours(domain.WorkingHours{TimeZone: "UTC", Start: 9 * 60, End: 9 * 60}))
+	assert.False(t, validWorkingHours(domain.WorkingHours{TimeZone: "UTC", Start: 9 * 60, End: 24 * 60}))
+}
+
+func TestFillReviewers_WorkingHoursPreferred(t *testing.T) {
+	now := time.Date(2025, time.July, 1, 10, 0, 0, 0, time.UTC)
+	pr := &domain.PullRequest{
+		AuthorID:    "author1",
+		ReviewersID: []string{},
+	}
+
+	onShift := domain.WorkingHours{TimeZone: "Europe/Moscow", Start: 9 * 60, End: 18 * 60}
+	offShift := domain.WorkingHours{TimeZone: "Europe/Berlin", Start: 20 * 60, End: 23 * 60}
+	team := &domain.Team{
+		Name: "test-team",
+		Members: []*domain.User{
+			{ID: "author1", Name: "Author", TeamName: "test-team", IsActive: true},
+			{ID: "user2", Name: "User 2", TeamName: "test-team", IsActive: true, WorkingHours: onShift},
+			{ID: "user3", Name: "User 3", TeamName: "test-team", IsActive: true, WorkingHours: offShift},
+			{ID: "user4", Name: "User 4", TeamName: "test-team", IsActive: true, WorkingHours: onShift},
+		},
+	}
+	opts := domain.AssignmentOptions{Strategy: domain.StrategyWorkingHours}
+
+	assert.NoError(t, newAssignment(team, opts, now).fillReviewers(pr))
+
+	assert.ElementsMatch(t, []string{"user2", "user4"}, pr.ReviewersID)
+}
+
+func TestFillReviewers_WorkingHoursFallback(t *testing.T) {
+	now := time.Date(2025, time.July, 1, 10, 0, 0, 0, time.UTC)
+	pr := &domain.PullRequest{
+		AuthorID:    "author1",
+		ReviewersID: []string{},
+	}
+
+	offShift := domain.WorkingHours{TimeZone: "Europe/Berlin", Start: 20 * 60, End: 23 * 60}
+	team := &domain.Team{
+		Name: "test-team",
+		Members: []*domain.User{
+			{ID: "author1", Name: "Author", TeamName: "test-team", IsActive: true},
+			{ID: "user2", Name: "User 2", TeamName: "test-team", IsActive: true, WorkingHours: offShift},
+			{ID: "user3", Name: "User 3", TeamName: "test-team", IsActive: true},
+		},
+	}
+	opts := domain.AssignmentOptions{Strategy: domain.StrategyWorkingHours}
+
+	assert.NoError(t, newAssignment(team, opts, now).fillReviewers(pr))
+
+	assert.Len(t, pr.ReviewersID, 2)
+	assert.Contains(t, pr.ReviewersID, "user3")
+}
+
+func TestFillReviewers_OwnersFirst(t *testing.T) {
+	pr := &domain.PullRequest{
+		AuthorID:    "author1",
+		ReviewersID: []string{},
+	}
+
+	team := &domain.Team{
+		Name: "test-team",
+		Members: []*domain.User{
+			{ID: "author1", Name: "Author", TeamName: "test-team", IsActive: true},
+			{ID: "dba", Name: "DBA", TeamName: "test-team", IsActive: true, Tags: []string{"db"}},
+			{ID: "front1", Name: "Front 1", TeamName: "test-team", IsActive: true, Tags: []string{"frontend"}},
+			{ID: "front2", Name: "Front 2", TeamName: "test-team", IsActive: false, Tags: []string{"frontend"}},
+			{ID: "user5", Name: "User 5", TeamName: "test-team", IsActive: true},
+		},
+		OwnerRules: []domain.OwnerRule{
+			{Pattern: "*.sql", Tags: []string{"db"}},
+			{Pattern: "/web/", Tags: []string{"frontend"}},
+		},
+	}
+	opts := domain.AssignmentOptions{Paths: []string{"web/src/app.tsx"}}
+
+	assert.NoError(t, newAssignment(team, opts, time.Now()).fillReviewers(pr))
+
+	assert.Len(t, pr.ReviewersID, 2)
+	assert.Equal(t, "front1", pr.ReviewersID[0])
+}
+
+func TestFillReviewers_EveryOwnerRuleCovered(t *testing.T) {
+	pr := &domain.PullRequest{
+		AuthorID:    "author1",
+		ReviewersID: []string{},
+	}
+
+	team := &domain.Team{
+		Name: "test-team",
+		Members: []*domain.User{
+			{ID: "author1", Name: "Author", TeamName: "test-team", IsActive: true},
+			{ID: "dba", Name: "DBA", TeamName: "test-team", IsActive: true, Tags: []string{"db"}},
+			{ID: "front1", Name: "Front 1", TeamName: "test-team", IsActive: true, Tags: []string{"frontend"}},
+			{ID: "user4", Name: "User 4", TeamName: "test-team", IsActive: true},
+			{ID: "user5", Name: "User 5", TeamName: "test-team", IsActive: true},
+		},
+		OwnerRules: []domain.OwnerRule{
+			{Pattern: "*.sql", Tags: []string{"db"}},
+			{Pattern: "/web/", UserIDs: []string{"front1"}},
+		},
+	}
+	opts := domain.AssignmentOptions{Paths: []string{"web/src/app.tsx", "migrations/001.sql"}}
+
+	assert.NoError(t, newAssignment(team, opts, time.Now()).fillReviewers(pr))
+
+	assert.Equal(t, []string{"front1", "dba"}, pr.ReviewersID)
+}
+
+func TestFillReviewers_RoleRequirementMet(t *testing.T) {
+	pr := &domain.PullRequest{
+		AuthorID:    "author1",
+		ReviewersID: []string{},
+	}
+
+	team := &domain.Team{
+		Name: "test-team",
+		Members: []*domain.User{
+			{ID: "author1", Name: "Author", TeamName: "test-team", IsActive: true, Role: domain.RoleSenior},
+			{ID: "junior1", Name: "Junior 1", TeamName: "test-team", IsActive: true, Role: domain.RoleJunior},
+			{ID: "junior2", Name: "Junior 2", TeamName: "test-team", IsActive: true, Role: domain.RoleJunior},
+			{ID: "junior3", Name: "Junior 3", TeamName: "test-team", IsActive: true, Role: domain.RoleJunior},
+			{ID: "senior1", Name: "Senior 1", TeamName: "test-team", IsActive: true, Role: domain.RoleSenior},
+		},
+		RoleRequirements: []domain.RoleRequirement{{MinRole: domain.RoleSenior, Count: 1}},
+	}
+
+	err := newAssignment(team, domain.AssignmentOptions{}, time.Now()).fillReviewers(pr)
+
+	assert.NoError(t, err)
+	assert.Len(t, pr.ReviewersID, 2)
+	assert.Contains(t, pr.ReviewersID, "senior1")
+}
+
+func TestFillReviewers_RoleRequirementUnmet(t *testing.T) {
+	pr := &domain.PullRequest{
+		AuthorID:    "author1",
+		ReviewersID: []string{},
+	}
+
+	team := &domain.Team{
+		Name: "test-team",
+		Members: []*domain.User{
+			{ID: "author1", Name: "Author", TeamName: "test-team", IsActive: true, Role: domain.RoleSenior},
+			{ID: "junior1", Name: "Junior 1", TeamName: "test-team", IsActive: true, Role: domain.RoleJunior},
+			{ID: "senior1", Name: "Senior 1", TeamName: "test-team", IsActive: false, Role: domain.RoleSenior},
+		},
+		RoleRequirements: []domain.RoleRequirement{{MinRole: domain.RoleSenior, Count: 1}},
+	}
+
+	err := newAssignment(team, domain.AssignmentOptions{}, time.Now()).fillReviewers(pr)
+
+	assert.ErrorIs(t, err, domain.ErrRoleRequirement)
+	assert.Contains(t, err.Error(), "senior")
+}
+
+func TestReplacementFor_KeepsRoleRequirement(t *testing.T) {
+	pr := &domain.PullRequest{
+		AuthorID:    "author1",
+		ReviewersID: []string{"junior1", "senior1"},
+	}
+
+	team := &domain.Team{
+		Name: "test-team",
+		Members: []*domain.User{
+			{ID: "author1", Name: "Author", TeamName: "test-team", IsActive: true},
+			{ID: "junior1", Name: "Junior 1", TeamName: "test-team", IsActive: true, Role: domain.RoleJunior},
+			{ID: "junior2", Name: "Junior 2", TeamName: "test-team", IsActive: true, Role: domain.RoleJunior},
+			{ID: "senior1", Name: "Senior 1", TeamName: "test-team", IsActive: true, Role: domain.RoleSenior},
+			{ID: "lead1", Name: "Lead 1", TeamName: "test-team", IsActive: true, Role: domain.RoleLead},
+		},
+		RoleRequirements: []domain.RoleRequirement{{MinRole: domain.RoleSenior, Count: 1}},
+	}
+	a := newAssignment(team, domain.AssignmentOptions{}, time.Now())
+
+	replacement, err := a.replacementFor(pr, "senior1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "lead1", replacement.ID)
+
+	team.Members[4].IsActive = false
+	replacement, err = a.replacementFor(pr, "senior1")
+
+	assert.Nil(t, replacement)
+	assert.ErrorIs(t, err, domain.ErrRoleRequirement)
+}
+
+func TestWeight_PairAvoidance(t *testing.T) {
+	team := &domain.Team{Name: "test-team"}
+	fresh := &domain.User{ID: "fresh", IsActive: true}
+	frequent := &domain.User{ID: "frequent", IsActive: true}
+
+	random := newAssignment(team, domain.AssignmentOptions{}, time.Now())
+	random.recentReviews = map[string]int{"frequent": 3}
+	assert.Equal(t, random.weight(fresh), random.weight(frequent))
+
+	avoiding := newAssignment(team, domain.AssignmentOptions{Strategy: domain.StrategyPairAvoidance}, time.Now())
+	avoiding.recentReviews = map[string]int{"frequent": 3}
+	assert.Equal(t, 1.0, avoiding.weight(fresh))
+	assert.Less(t, avoiding.weight(frequent), avoiding.weight(fresh))
+}
+
+func TestFillReviewers_PairAvoidancePrefersFreshPairs(t *testing.T) {
+	team := &domain.Team{
+		Name: "test-team",
+		Members: []*domain.User{
+			{ID: "author1", Name: "Author", TeamName: "test-team", IsActive: true},
+			{ID: "user2", Name: "User 2", TeamName: "test-team", IsActive: true},
+			{ID: "user3", Name: "User 3", TeamName: "test-team", IsActive: true},
+			{ID: "user4", Name: "User 4", TeamName: "test-team", IsActive: true},
+		},
+	}
+	opts := domain.AssignmentOptions{Strategy: domain.StrategyPairAvoidance}
+	rnd := rand.New(rand.NewSource(1))
+
+	picks := make([][]string, 0, 4)
+	for i := 0; i < 4; i++ {
+		pr := &domain.PullRequest{AuthorID: "author1", ReviewersID: []string{}}
+		a := newAssignment(team, opts, time.Now())
+		a.rnd = rnd
+		a.recentReviews = map[string]int{"user2": 10}
+		assert.NoError(t, a.fillReviewers(pr))
+		picks = append(picks, pr.ReviewersID)
+	}
+
+	assert.Equal(t, [][]string{
+		{"user4", "user3"},
+		{"user4", "user3"},
+		{"user3", "user4"},
+		{"user3", "user4"},
+	}, picks)
+}
+
+func TestPairWindowStart(t *testing.T) {
+	now := time.Date(2025, time.July, 31, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, now.AddDate(0, 0, -defaultPairWindow), pairWindowStart(domain.AssignmentOptions{}, now))
+	assert.Equal(t, now.AddDate(0, 0, -7), pairWindowStart(domain.AssignmentOptions{PairWindowDays: 7}, now))
+}
+
+func TestCandidateError(t *testing.T) {
+	pr := &domain.PullRequest{
+		AuthorID:    "author1",
+		ReviewersID: []string{"reviewer1"},
+	}
+
+	testCases := []struct {
+		name     string
+		user     *domain.User
+		expected error
+	}{
+		{"Valid candidate", &domain.User{ID: "candidate1", IsActive: true}, nil},
+		{"Author", &domain.User{ID: "author1", IsActive: true}, domain.ErrReviewerIsAuthor},
+		{"Already assigned", &domain.User{ID: "reviewer1", IsActive: true}, domain.ErrAlreadyAssigned},
+		{"Inactive", &domain.User{ID: "candidate1", IsActive: false}, domain.ErrReviewerInactive},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, candidateError(pr, tc.user))
+		})
+	}
+}
+
+func TestRemoveReviewer(t *testing.T) {
+	pr := &domain.PullRequest{
+		AuthorID:    "author1",
+		ReviewersID: []string{"reviewer1", "reviewer2"},
+	}
+
+	assert.NoError(t, removeReviewer(pr, "reviewer1"))
+	assert.Equal(t, []string{"reviewer2"}, pr.ReviewersID)
+	assert.Equal(t, domain.ErrNotAssigned, removeReviewer(pr, "reviewer1"))
+}
+
+func TestNewReviewer_SkipsDecliners(t *testing.T) {
+	team := &domain.Team{
+		Name: "team1",
+		Members: []*domain.User{
+			{ID: "author1", IsActive: true},
+			{ID: "decliner1", IsActive: true},
+			{ID: "candidate1", IsActive: true},
+		},
+	}
+	pr := &domain.PullRequest{
+		AuthorID:    "author1",
+		ReviewersID: []string{},
+		DeclinedIDs: []string{"decliner1"},
+	}
+
+	for i := 0; i < 20; i++ {
+		reviewer := newAssignment(team, domain.AssignmentOptions{}, time.Now()).newReviewer(pr)
+		assert.Equal(t, "candidate1", reviewer.ID)
+	}
+}
+
+func TestValidDeclineReason(t *testing.T) {
+	assert.True(t, validDeclineReason("too busy"))
+	assert.False(t, validDeclineReason(""))
+	assert.False(t, validDeclineReason("   "))
+	assert.False(t, validDeclineReason(strings.Repeat("a", maxDeclineReason+1)))
+}
+
+func TestExplain(t *testing.T) {
+	zero := 0
+	team := &domain.Team{
+		Name: "team1",
+		Members: []*domain.User{
+			{ID: "author1", IsActive: true},
+			{ID: "reviewer1", IsActive: true},
+			{ID: "inactive1", IsActive: false},
+			{ID: "decliner1", IsActive: true},
+			{ID: "lazy1", IsActive: true, ReviewWeight: &zero},
+			{ID: "spare1", IsActive: true},
+			{ID: "spare2", IsActive: true},
+		},
+	}
+	pr := &domain.PullRequest{
+		AuthorID:    "author1",
+		ReviewersID: []string{"reviewer1"},
+		DeclinedIDs: []string{"decliner1"},
+	}
+	a := newAssignment(team, domain.AssignmentOptions{}, time.Now())
+	a.rnd = rand.New(rand.NewSource(1))
+
+	err := a.fillReviewers(pr)
+	explanation := a.explain(pr, []string{"reviewer1"}, pr.ReviewersID[1:])
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"reviewer1", "spare2"}, pr.ReviewersID)
+	assert.Equal(t, domain.StrategyRandom, explanation.Strategy)
+	assert.Equal(t, []string{"spare1", "spare2"}, explanation.Candidates)
+	assert.Equal(t, []domain.Exclusion{
+		{UserID: "author1", Reason: domain.ExcludedAuthor},
+		{UserID: "reviewer1", Reason: domain.ExcludedAlreadyAssigned},
+		{UserID: "inactive1", Reason: domain.ExcludedInactive},
+		{UserID: "decliner1", Reason: domain.ExcludedDeclined},
+		{UserID: "lazy1", Reason: domain.ExcludedZeroWeight},
+	}, explanation.Excluded)
+}
+
+func TestExplain_NotOwner(t *testing.T) {
+	team := &domain.Team{
+		Name: "team1",
+		Members: []*domain.User{
+			{ID: "author1", IsActive: true},
+			{ID: "owner1", IsActive: true},
+			{ID: "owner2", IsActive: true},
+			{ID: "other1", IsActive: true},
+		},
+		OwnerRules: []domain.OwnerRule{
+			{Pattern: "/api/", UserIDs: []string{"owner1"}},
+			{Pattern: "/web/", UserIDs: []string{"owner2"}},
+		},
+	}
+	pr := &domain.PullRequest{AuthorID: "author1"}
+	opts := domain.AssignmentOptions{Paths: []string{"api/handler.go", "web/page.tsx"}}
+	a := newAssignment(team, opts, time.Now())
+
+	err := a.fillReviewers(pr)
+	explanation := a.explain(pr, nil, pr.ReviewersID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"owner1", "owner2"}, pr.ReviewersID)
+	assert.Equal(t, []string{"owner1", "owner2"}, explanation.Candidates)
+	assert.Equal(t, []domain.Exclusion{
+		{UserID: "author1", Reason: domain.ExcludedAuthor},
+		{UserID: "other1", Reason: domain.ExcludedNotOwner},
+	}, explanation.Excluded)
+}
+
+func TestExplain_RoleRequirement(t *testing.T) {
+	team := &domain.Team{
+		Name: "team1",
+		Members: []*domain.User{
+			{ID: "author1", IsActive: true},
+			{ID: "junior1", IsActive: true, Role: domain.RoleJunior},
+			{ID: "junior2", IsActive: true, Role: domain.RoleJunior},
+			{ID: "senior1", IsActive: true, Role: domain.RoleSenior},
+		},
+		RoleRequirements: []domain.RoleRequirement{{MinRole: domain.RoleSenior, Count: 1}},
+	}
+	pr := &domain.PullRequest{AuthorID: "author1", ReviewersID: []string{"junior1"}}
+	a := newAssignment(team, domain.AssignmentOptions{}, time.Now())
+
+	err := a.fillReviewers(pr)
+	explanation := a.explain(pr, []string{"junior1"}, pr.ReviewersID[1:])
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"junior1", "senior1"}, pr.ReviewersID)
+	assert.Equal(t, []string{"senior1"}, explanation.Candidates)
+	assert.Equal(t, []domain.Exclusion{
+		{UserID: "author1", Reason: domain.ExcludedAuthor},
+		{UserID: "junior1", Reason: domain.ExcludedAlreadyAssigned},
+		{UserID: "junior2", Reason: domain.ExcludedRoleRequirement},
+	}, explanation.Excluded)
+}
+
+func TestExplain_AtCapacity(t *testing.T) {
+	team := &domain.Team{
+		Name: "team1",
+		Members: []*domain.User{
+			{ID: "author1", IsActive: true},
+			{ID: "user1", IsActive: true},
+			{ID: "user2", IsActive: true},
+		},
+	}
+	design := &domain.Team{
+		Name: "design",
+		Members: []*domain.User{
+			{ID: "design1", IsActive: true},
+			{ID: "design2", IsActive: true},
+		},
+	}
+	mobile := &domain.Team{
+		Name: "mobile",
+		Members: []*domain.User{
+			{ID: "mobile1", IsActive: true},
+			{ID: "mobile2", IsActive: true},
+		},
+	}
+	pr := &domain.PullRequest{
+		AuthorID:      "author1",
+		ReviewersID:   []string{"user1", "design1"},
+		ReviewerTeams: map[string]string{"user1": "team1", "design1": "design"},
+		ExtraTeams:    []string{"design", "mobile"},
+	}
+	a := newAssignment(team, domain.AssignmentOptions{}, time.Now())
+	a.rnd = rand.New(rand.NewSource(1))
+	a.extraTeams = []*domain.Team{design, mobile}
+
+	err := a.fillReviewers(pr)
+	explanation := a.explain(pr, []string{"user1", "design1"}, pr.ReviewersID[2:])
+
+	assert.NoError(t, err)
+	assert.Len(t, pr.ReviewersID, 3)
+	assert.ElementsMatch(t, []string{"mobile1", "mobile2"}, explanation.Candidates, "a candidate who lost the draw stays a candidate")
+	assert.Equal(t, []domain.Exclusion{
+		{UserID: "author1", Reason: domain.ExcludedAuthor},
+		{UserID: "user1", Reason: domain.ExcludedAlreadyAssigned},
+		{UserID: "user2", Reason: domain.ExcludedAtCapacity},
+		{UserID: "design1", Reason: domain.ExcludedAlreadyAssigned},
+		{UserID: "design2", Reason: domain.ExcludedAtCapacity},
+	}, explanation.Excluded)
+}
+
+func TestExplain_NotPicked(t *testing.T) {
+	team := &domain.Team{
+		Name: "team1",
+		Members: []*domain.User{
+			{ID: "author1", IsActive: true},
+			{ID: "user1", IsActive: true},
+		},
+	}
+	pr := &domain.PullRequest{AuthorID: "author1"}
+	a := newAssignment(team, domain.AssignmentOptions{}, time.Now())
+
+	explanation := a.explain(pr, nil, nil)
+
+	assert.Empty(t, explanation.Candidates)
+	assert.Equal(t, []domain.Exclusion{
+		{UserID: "author1", Reason: domain.ExcludedAuthor},
+		{UserID: "user1", Reason: domain.ExcludedNotPicked},
+	}, explanation.Excluded)
+}
+
+func TestExplain_OutsideWorkingHours(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	day := domain.WorkingHours{TimeZone: "UTC", Start: 9 * 60, End: 18 * 60}
+	night := domain.WorkingHours{TimeZone: "UTC", Start: 22 * 60, End: 6 * 60}
+	team := &domain.Team{
+		Name: "team1",
+		Members: []*domain.User{
+			{ID: "author1", IsActive: true},
+			{ID: "awake1", IsActive: true, WorkingHours: day},
+			{ID: "asleep1", IsActive: true, WorkingHours: night},
+		},
+	}
+	design := &domain.Team{
+		Name: "design",
+		Members: []*domain.User{
+			{ID: "asleep2", IsActive: true, WorkingHours: night},
+			{ID: "asleep3", IsActive: true, WorkingHours: night},
+		},
+	}
+	pr := &domain.PullRequest{AuthorID: "author1", ExtraTeams: []string{"design"}}
+	opts := domain.AssignmentOptions{Strategy: domain.StrategyWorkingHours}
+	a := newAssignment(team, opts, now)
+	a.rnd = rand.New(rand.NewSource(1))
+	a.extraTeams = []*domain.Team{design}
+
+	err := a.fillReviewers(pr)
+	explanation := a.explain(pr, nil, pr.ReviewersID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"awake1", "asleep3"}, pr.ReviewersID)
+	assert.Equal(t, domain.StrategyWorkingHours, explanation.Strategy)
+	assert.Equal(t, []string{"awake1", "asleep2", "asleep3"}, explanation.Candidates)
+	assert.Equal(t, []domain.Exclusion{
+		{UserID: "author1", Reason: domain.ExcludedAuthor},
+		{UserID: "asleep1", Reason: domain.ExcludedOffHours},
+	}, explanation.Excluded)
+}
+
+func TestNewReviewer_SeededSourceIsReproducible(t *testing.T) {
+	team := &domain.Team{
+		Name: "team1",
+		Members: []*domain.User{
+			{ID: "author1", IsActive: true},
+			{ID: "user1", IsActive: true},
+			{ID: "user2", IsActive: true},
+			{ID: "user3", IsActive: true},
+			{ID: "user4", IsActive: true},
+		},
+	}
+	pr := &domain.PullRequest{AuthorID: "author1"}
+	a := newAssignment(team, domain.AssignmentOptions{}, time.Now())
+	a.rnd = rand.New(rand.NewSource(1))
+
+	picks := make([]string, 0, 5)
+	for i := 0; i < 5; i++ {
+		picks = append(picks, a.newReviewer(pr).ID)
+	}
+
+	assert.Equal(t, []string{"user3", "user4", "user3", "user2", "user2"}, picks)
+}
+
+func TestWeight_Weighted(t *testing.T) {
+	team := &domain.Team{Name: "test-team"}
+	zero, triple := 0, 3
+	regular := &domain.User{ID: "regular", IsActive: true}
+	partTime := &domain.User{ID: "part-time", IsActive: true, ReviewWeight: &zero}
+	heavy := &domain.User{ID: "heavy", IsActive: true, ReviewWeight: &triple}
+
+	weighted := newAssignment(team, domain.AssignmentOptions{Strategy: domain.StrategyWeighted}, time.Now())
+	assert.Equal(t, 1.0, weighted.weight(regular))
+	assert.Equal(t, 0.0, weighted.weight(partTime))
+	assert.Equal(t, 3.0, weighted.weight(heavy))
+
+	random := newAssignment(team, domain.AssignmentOptions{}, time.Now())
+	assert.Equal(t, random.weight(regular), random.weight(heavy))
+}
+
+func TestNewReviewer_WeightedFollowsWeights(t *testing.T) {
+	light, heavy := 1, 9
+	team := &domain.Team{
+		Name: "team1",
+		Members: []*domain.User{
+			{ID: "author1", IsActive: true},
+			{ID: "light1", IsActive: true, ReviewWeight: &light},
+			{ID: "heavy1", IsActive: true, ReviewWeight: &heavy},
+		},
+	}
+	pr := &domain.PullRequest{AuthorID: "author1"}
+	a := newAssignment(team, domain.AssignmentOptions{Strategy: domain.StrategyWeighted}, time.Now())
+	a.rnd = rand.New(rand.NewSource(1))
+
+	picks := make([]string, 0, 10)
+	for i := 0; i < 10; i++ {
+		picks = append(picks, a.newReviewer(pr).ID)
+	}
+
+	assert.Equal(t, []string{
+		"heavy1", "heavy1", "heavy1", "heavy1", "heavy1",
+		"heavy1", "light1", "heavy1", "light1", "heavy1",
+	}, picks)
+}
+
+func TestNewReviewer_ZeroWeightNeverAutoAssigned(t *testing.T) {
+	zero := 0
+	team := &domain.Team{
+		Name: "team1",
+		Members: []*domain.User{
+			{ID: "author1", IsActive: true},
+			{ID: "manual1", IsActive: true, ReviewWeight: &zero},
+		},
+	}
+	pr := &domain.PullRequest{AuthorID: "author1"}
+
+	for _, strategy := range []domain.AssignmentStrategy{domain.StrategyRandom, domain.StrategyWeighted} {
+		reviewer := newAssignment(team, domain.AssignmentOptions{Strategy: strategy}, time.Now()).newReviewer(pr)
+		assert.Nil(t, reviewer)
+	}
+	assert.NoError(t, candidateError(pr, team.Members[1]))
+}
+
+func TestFillReviewers_RoundRobinCyclesThroughTeam(t *testing.T) {
+	team := &domain.Team{
+		Name: "team1",
+		Members: []*domain.User{
+			{ID: "user3", IsActive: true},
+			{ID: "author1", IsActive: true},
+			{ID: "user1", IsActive: true},
+			{ID: "user2", IsActive: false},
+			{ID: "user4", IsActive: true},
+		},
+	}
+	a := newAssignment(team, domain.AssignmentOptions{Strategy: domain.StrategyRoundRobin}, time.Now())
+
+	picks := make([][]string, 0, 3)
+	for i := 0; i < 3; i++ {
+		pr := &domain.PullRequest{AuthorID: "author1", ReviewersID: []string{}}
+		assert.NoError(t, a.fillReviewers(pr))
+		picks = append(picks, pr.ReviewersID)
+	}
+
+	assert.Equal(t, [][]string{
+		{"user1", "user3"},
+		{"user4", "user1"},
+		{"user3", "user4"},
+	}, picks)
+	assert.Equal(t, "user4", a.cursor)
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/J0hnLenin/ReviewRequest/domain"
+)
+
+func roleRank(r domain.Role) int {
+	switch r {
+	case domain.RoleJunior:
+		return 1
+	case domain.RoleMiddle:
+		return 2
+	case domain.RoleSenior:
+		return 3
+	case domain.RoleLead:
+		return 4
+	}
+	return 0
+}
+
+func validRole(r domain.Role) bool {
+	return r == "" || roleRank(r) > 0
+}
+
+func validRequirements(requirements []domain.RoleRequirement) bool {
+	total := 0
+	for _, req := range requirements {
+		if roleRank(req.MinRole) == 0 || req.Count <= 0 {
+			return false
+		}
+		total += req.Count
+	}
+	return total <= domain.MaxReviewers
+}
+
+func requiredCount(requirements []domain.RoleRequirement) int {
+	total := 0
+	for _, req := range requirements {
+		total += req.Count
+	}
+	return total
+}
+
+// unmetRequirements matches reviewers to requirements, strictest requirement
+// first and lowest qualifying reviewer first, and returns what is left.
+func unmetRequirements(requirements []domain.RoleRequirement, reviewers []*domain.User) []domain.RoleRequirement {
+	ranks := make([]int, len(reviewers))
+	for i, reviewer := range reviewers {
+		ranks[i] = roleRank(reviewer.Role)
+	}
+	slices.Sort(ranks)
+	used := make([]bool, len(ranks))
+
+	sorted := slices.Clone(requirements)
+	slices.SortStableFunc(sorted, func(a, b domain.RoleRequirement) int {
+		return roleRank(b.MinRole) - roleRank(a.MinRole)
+	})
+
+	unmet := make([]domain.RoleRequirement, 0)
+	for _, req := range sorted {
+		missing := req.Count
+		for i := range ranks {
+			if missing == 0 {
+				break
+			}
+			if !used[i] && ranks[i] >= roleRank(req.MinRole) {
+				used[i] = true
+				missing--
+			}
+		}
+		if missing > 0 {
+			unmet = append(unmet, domain.RoleRequirement{MinRole: req.MinRole, Count: missing})
+		}
+	}
+	return unmet
+}
+
+func requirementError(req domain.RoleRequirement) error {
+	return fmt.Errorf("%w: need %d more %s or above", domain.ErrRoleRequirement, req.Count, req.MinRole)
+}
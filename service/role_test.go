@@ -0,0 +1,52 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/J0hnLenin/ReviewRequest/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidRequirements(t *testing.T) {
+	assert.True(t, validRequirements(nil))
+	assert.True(t, validRequirements([]domain.RoleRequirement{{MinRole: domain.RoleSenior, Count: 1}}))
+	assert.True(t, validRequirements([]domain.RoleRequirement{
+		{MinRole: domain.RoleLead, Count: 1},
+		{MinRole: domain.RoleMiddle, Count: 1},
+	}))
+	assert.False(t, validRequirements([]domain.RoleRequirement{{MinRole: "architect", Count: 1}}))
+	assert.False(t, validRequirements([]domain.RoleRequirement{{MinRole: domain.RoleSenior, Count: 0}}))
+	assert.False(t, validRequirements([]domain.RoleRequirement{{MinRole: domain.RoleJunior, Count: domain.MaxReviewers + 1}}))
+}
+
+func TestUnmetRequirements(t *testing.T) {
+	requirements := []domain.RoleRequirement{
+		{MinRole: domain.RoleMiddle, Count: 1},
+		{MinRole: domain.RoleSenior, Count: 1},
+	}
+	junior := &domain.User{ID: "junior", Role: domain.RoleJunior}
+	middle := &domain.User{ID: "middle", Role: domain.RoleMiddle}
+	senior := &domain.User{ID: "senior", Role: domain.RoleSenior}
+	lead := &domain.User{ID: "lead", Role: domain.RoleLead}
+
+	testCases := []struct {
+		name      string
+		reviewers []*domain.User
+		expected  []domain.RoleRequirement
+	}{
+		{"Nobody assigned", nil, []domain.RoleRequirement{
+			{MinRole: domain.RoleSenior, Count: 1},
+			{MinRole: domain.RoleMiddle, Count: 1},
+		}},
+		{"Senior and middle", []*domain.User{middle, senior}, []domain.RoleRequirement{}},
+		{"Lead covers senior slot", []*domain.User{lead, middle}, []domain.RoleRequirement{}},
+		{"Single lead covers one slot", []*domain.User{lead}, []domain.RoleRequirement{{MinRole: domain.RoleMiddle, Count: 1}}},
+		{"Junior covers nothing", []*domain.User{junior, senior}, []domain.RoleRequirement{{MinRole: domain.RoleMiddle, Count: 1}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, unmetRequirements(requirements, tc.reviewers))
+		})
+	}
+}
@@ -2,6 +2,9 @@ package service
 
 import (
 	"context"
+	"math/rand"
+	"sync"
+	"time"
 
 	"github.com/J0hnLenin/ReviewRequest/domain"
 )
@@ -9,26 +12,109 @@ import (
 type Repository interface {
 	GetTeamByName(ctx context.Context, name string) (*domain.Team, error)
 	GetTeamByUser(ctx context.Context, userID string) (*domain.Team, error)
+	GetTeamsByNames(ctx context.Context, names []string) ([]*domain.Team, error)
 	SaveTeam(ctx context.Context, t *domain.Team) error
 	ChangeTeamActive(ctx context.Context, name string, active bool) (*domain.Team, error)
+	SaveTeamOwnerRules(ctx context.Context, name string, rules []domain.OwnerRule) error
+	SaveTeamRoleRequirements(ctx context.Context, name string, requirements []domain.RoleRequirement) error
+	SaveTeamSLA(ctx context.Context, name string, policy domain.SLAPolicy) error
 
 	GetUserById(ctx context.Context, id string) (*domain.User, error)
 	SaveUser(ctx context.Context, u *domain.User) error
 
 	GetPRByAuthor(ctx context.Context, id string) ([]*domain.PullRequest, error)
 	GetPRById(ctx context.Context, id string) (*domain.PullRequest, error)
+	// ListPRs returns PRs matching filter, newest first.
+	ListPRs(ctx context.Context, filter domain.PRFilter) ([]*domain.PullRequest, error)
 	GetPRAndTeam(ctx context.Context, id string) (*domain.PullRequest, *domain.Team, error)
-	SavePR(ctx context.Context, pr *domain.PullRequest) error
+	SavePR(ctx context.Context, pr *domain.PullRequest, events []domain.ReviewerEvent) error
+	// GetRecentReviewCounts counts, per reviewer, the author's PRs the reviewer
+	// was assigned to since the given time, including assignments later
+	// reassigned, declined or removed.
+	GetRecentReviewCounts(ctx context.Context, authorID string, since time.Time) (map[string]int, error)
+	// GetUnderReviewedPRs returns IDs of open PRs that may wait for a reviewer
+	// from the team, oldest first: PRs authored in the team with fewer
+	// reviewers than their slots, and PRs that asked the team as an extra team
+	// but have no reviewer representing it. A PR without extra teams has
+	// maxReviewers slots; one with extra teams has crossTeamAuthorSlots plus
+	// one per extra team.
+	GetUnderReviewedPRs(ctx context.Context, teamName string, maxReviewers int, crossTeamAuthorSlots int) ([]string, error)
+	// AdvanceRoundRobinCursor locks the team's cursor, passes it to advance and
+	// stores the returned cursor; when advance fails the cursor is left as it
+	// was. Concurrent callers are served one at a time.
+	AdvanceRoundRobinCursor(ctx context.Context, teamName string, advance func(cursor string) (string, error)) error
+	GetOverdueReviews(ctx context.Context, now time.Time) ([]domain.PendingReview, error)
 
-	GetStatistics(ctx context.Context) (*domain.Statistics, error)
+	// TryAdvisoryLock returns ok=false without waiting when another process holds the lock.
+	TryAdvisoryLock(ctx context.Context, key int64) (unlock func(), ok bool, err error)
+
+	GetStatistics(ctx context.Context, period domain.TimeRange) (*domain.Statistics, error)
+	// GetOpenReviewLoads returns the number of open reviews of every active
+	// user, counting PRs created within period.
+	GetOpenReviewLoads(ctx context.Context, period domain.TimeRange) ([]int, error)
+	// GetTeamStatistics fills counts and member load, or returns nil when the team does not exist.
+	GetTeamStatistics(ctx context.Context, teamName string) (*domain.TeamStatistics, error)
+	// GetLeaderboard returns one page of users ranked by metric and the number of ranked users.
+	GetLeaderboard(ctx context.Context, metric domain.LeaderboardMetric, period domain.TimeRange, limit int, offset int) ([]domain.LeaderboardEntry, int, error)
+	// GetUserProfile returns nil when the user does not exist.
+	GetUserProfile(ctx context.Context, userID string, period domain.TimeRange) (*domain.UserProfile, error)
+	// GetTimeSeries counts metric per bucket within period, for the team's
+	// authors when teamName is set. Buckets without events are omitted.
+	GetTimeSeries(ctx context.Context, metric domain.TimeSeriesMetric, bucket domain.TimeBucket, teamName string, period domain.TimeRange) ([]domain.TimeSeriesPoint, error)
+	// GetReviewLoad returns the current open PR count and open reviews per team.
+	GetReviewLoad(ctx context.Context) (*domain.ReviewLoad, error)
+	// GetPRTimelines returns the timelines of PRs created within period.
+	GetPRTimelines(ctx context.Context, period domain.TimeRange) ([]domain.PRTimeline, error)
 }
 
 type Service struct {
-	repo Repository
+	repo    Repository
+	rnd     *rand.Rand
+	topUps  chan string
+	metrics Recorder
+}
+
+type Option func(*Service)
+
+// WithRandSource makes reviewer picks draw from src instead of a time-seeded source.
+func WithRandSource(src rand.Source) Option {
+	return func(s *Service) {
+		s.rnd = rand.New(&lockedSource{src: src})
+	}
+}
+
+// WithSeed makes reviewer picks reproducible for the given seed.
+func WithSeed(seed int64) Option {
+	return WithRandSource(rand.NewSource(seed))
 }
 
-func NewService(r Repository) *Service {
-	return &Service{
-		repo: r,
+func NewService(r Repository, opts ...Option) *Service {
+	s := &Service{
+		repo:    r,
+		rnd:     rand.New(&lockedSource{src: rand.NewSource(time.Now().UnixNano())}),
+		topUps:  make(chan string, topUpQueueSize),
+		metrics: nopRecorder{},
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// lockedSource lets concurrent requests share one rand.Source.
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source
+}
+
+func (l *lockedSource) Int63() int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.src.Int63()
+}
+
+func (l *lockedSource) Seed(seed int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.src.Seed(seed)
 }
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/J0hnLenin/ReviewRequest/domain"
+)
+
+const maxSimulationRuns = 10000
+
+// PRSimulate runs the reviewer assignment for a hypothetical PR by authorID
+// without saving anything. The same seed always yields the same result. Runs
+// that leave a role requirement unmet still count; the first run's gap is
+// reported in the result.
+func (s *Service) PRSimulate(ctx context.Context, authorID string, opts domain.AssignmentOptions, runs int, seed int64) (*domain.Simulation, error) {
+	if !validStrategy(opts.Strategy) {
+		return nil, domain.ErrInvalidStrategy
+	}
+	if runs < 1 || runs > maxSimulationRuns {
+		return nil, domain.ErrInvalidSimulation
+	}
+
+	team, err := s.repo.GetTeamByUser(ctx, authorID)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	extraTeams, err := s.extraTeams(ctx, team, opts.ExtraTeams)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var recentReviews map[string]int
+	if opts.Strategy == domain.StrategyPairAvoidance {
+		recentReviews, err = s.repo.GetRecentReviewCounts(ctx, authorID, pairWindowStart(opts, now))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rnd := rand.New(rand.NewSource(seed))
+	cursor := team.RoundRobinCursor
+	simulation := &domain.Simulation{
+		Runs:   runs,
+		Counts: make(map[string]int),
+	}
+	for i := 0; i < runs; i++ {
+		a := newAssignment(team, opts, now)
+		a.recentReviews = recentReviews
+		a.rnd = rnd
+		a.cursor = cursor
+		a.extraTeams = extraTeams
+
+		pr := &domain.PullRequest{
+			AuthorID:    authorID,
+			Status:      domain.Open,
+			ReviewersID: make([]string, 0, domain.MaxReviewers),
+			ExtraTeams:  opts.ExtraTeams,
+		}
+		roleGap := a.fillReviewers(pr)
+		cursor = a.cursor
+		if i == 0 {
+			simulation.Reviewers = pr.ReviewersID
+			simulation.Explanation = a.explain(pr, nil, pr.ReviewersID)
+			if roleGap != nil {
+				simulation.RoleGap = roleGap.Error()
+			}
+		}
+		for _, reviewerID := range pr.ReviewersID {
+			simulation.Counts[reviewerID]++
+		}
+	}
+	return simulation, nil
+}
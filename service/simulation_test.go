@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/J0hnLenin/ReviewRequest/domain"
+	"github.com/J0hnLenin/ReviewRequest/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func simulationTeam() *domain.Team {
+	return &domain.Team{
+		Name: "team1",
+		Members: []*domain.User{
+			{ID: "author1", TeamName: "team1", IsActive: true},
+			{ID: "user1", TeamName: "team1", IsActive: true},
+			{ID: "user2", TeamName: "team1", IsActive: true},
+			{ID: "user3", TeamName: "team1", IsActive: true},
+			{ID: "user4", TeamName: "team1", IsActive: false},
+		},
+	}
+}
+
+func TestPRSimulate_Success(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+
+	mockRepo.On("GetTeamByUser", mock.Anything, "author1").Return(simulationTeam(), nil)
+
+	// Act
+	simulation, err := service.PRSimulate(context.Background(), "author1", domain.AssignmentOptions{}, 300, 42)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, simulation.Reviewers, 2)
+	assert.NotNil(t, simulation.Explanation)
+	assert.Equal(t, 300, simulation.Runs)
+	total := 0
+	for userID, count := range simulation.Counts {
+		assert.Contains(t, []string{"user1", "user2", "user3"}, userID)
+		total += count
+	}
+	assert.Equal(t, 600, total)
+	mockRepo.AssertNotCalled(t, "SavePR")
+}
+
+func TestPRSimulate_SameSeedSameResult(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+
+	mockRepo.On("GetTeamByUser", mock.Anything, "author1").Return(simulationTeam(), nil)
+
+	// Act
+	first, firstErr := service.PRSimulate(context.Background(), "author1", domain.AssignmentOptions{}, 50, 7)
+	second, secondErr := service.PRSimulate(context.Background(), "author1", domain.AssignmentOptions{}, 50, 7)
+
+	// Assert
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.Equal(t, first, second)
+}
+
+func TestPRSimulate_InvalidRuns(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+
+	// Act
+	simulation, err := service.PRSimulate(context.Background(), "author1", domain.AssignmentOptions{}, 0, 1)
+
+	// Assert
+	assert.Nil(t, simulation)
+	assert.Equal(t, domain.ErrInvalidSimulation, err)
+	mockRepo.AssertNotCalled(t, "GetTeamByUser")
+}
+
+func TestPRSimulate_AuthorNotFound(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+
+	mockRepo.On("GetTeamByUser", mock.Anything, "ghost").Return(nil, nil)
+
+	// Act
+	simulation, err := service.PRSimulate(context.Background(), "ghost", domain.AssignmentOptions{}, 10, 1)
+
+	// Assert
+	assert.Nil(t, simulation)
+	assert.Equal(t, domain.ErrNotFound, err)
+}
+
+func TestPRSimulate_RoleRequirementUnmet(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+
+	team := simulationTeam()
+	team.Members[1].Role = domain.RoleSenior
+	team.RoleRequirements = []domain.RoleRequirement{{MinRole: domain.RoleSenior, Count: 2}}
+	mockRepo.On("GetTeamByUser", mock.Anything, "author1").Return(team, nil)
+
+	// Act
+	simulation, err := service.PRSimulate(context.Background(), "author1", domain.AssignmentOptions{}, 20, 1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user1"}, simulation.Reviewers)
+	assert.Equal(t, "reviewer role requirement cannot be met: need 1 more senior or above", simulation.RoleGap)
+	assert.Equal(t, map[string]int{"user1": 20}, simulation.Counts)
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/J0hnLenin/ReviewRequest/domain"
+)
+
+// slaLockKey is the Postgres advisory lock key that keeps the SLA worker
+// running on a single replica at a time.
+const slaLockKey int64 = 0x534c41
+
+func validSLAPolicy(p domain.SLAPolicy) bool {
+	if p.Hours < 0 {
+		return false
+	}
+	switch p.Action {
+	case "", domain.SLANotify, domain.SLAAddReviewer, domain.SLAReassign:
+		return true
+	}
+	return false
+}
+
+// RunSLAWorker escalates overdue reviews every interval until ctx is cancelled.
+func (s *Service) RunSLAWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		escalated, err := s.EscalateOverdueReviews(ctx, time.Now())
+		if err != nil {
+			slog.ErrorContext(ctx, "sla worker failed", "error", err)
+			continue
+		}
+		if escalated > 0 {
+			slog.InfoContext(ctx, "sla worker: escalated overdue reviews", "escalated", escalated)
+		}
+	}
+}
+
+// EscalateOverdueReviews applies each team's SLA policy to reviews pending
+// longer than allowed and returns how many were escalated. It does nothing
+// when another replica holds the worker lock.
+func (s *Service) EscalateOverdueReviews(ctx context.Context, now time.Time) (int, error) {
+	unlock, ok, err := s.repo.TryAdvisoryLock(ctx, slaLockKey)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, nil
+	}
+	defer unlock()
+
+	overdue, err := s.repo.GetOverdueReviews(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	escalated := 0
+	for _, review := range overdue {
+		done, err := s.escalateReview(ctx, review, now)
+		if err != nil {
+			slog.ErrorContext(ctx, "sla worker: escalation failed",
+				"pull_request_id", review.PullRequestID, "reviewer_id", review.ReviewerID, "error", err)
+			continue
+		}
+		if done {
+			escalated++
+		}
+	}
+	return escalated, nil
+}
+
+func (s *Service) escalateReview(ctx context.Context, review domain.PendingReview, now time.Time) (bool, error) {
+	pr, team, err := s.repo.GetPRAndTeam(ctx, review.PullRequestID)
+	if err != nil {
+		return false, err
+	}
+	if pr == nil || team == nil || pr.Status == domain.Merged || !prContainsReviewer(pr, review.ReviewerID) {
+		return false, nil
+	}
+
+	// The author's team policy decides the action, as it set the SLA hours;
+	// a replacement comes from the reviewer's own team, an added reviewer
+	// takes a free slot of the author's team.
+	authorTeam := team
+	team, err = s.representedTeam(ctx, pr, authorTeam, review.ReviewerID)
+	if err != nil {
+		return false, err
+	}
+	a := s.assignment(team, prOptions(pr, team), now)
+	action := domain.SLANotify
+	var events []domain.ReviewerEvent
+
+	switch authorTeam.SLA.Action {
+	case domain.SLAAddReviewer:
+		if len(ownReviewers(pr)) >= authorSlots(pr) {
+			break
+		}
+		own := s.assignment(authorTeam, prOptions(pr, authorTeam), now)
+		extra := own.pickOwnerFirst(pr, "", own.reachable(pr))
+		if extra != nil {
+			addReviewer(pr, extra.ID, authorTeam.Name)
+			events = append(events, reviewerEvent(pr, extra.ID, domain.ReviewerAssigned, now))
+			action = domain.SLAAddReviewer
+		}
+	case domain.SLAReassign:
+		replacement, err := a.replacementFor(pr, review.ReviewerID)
+		if err != nil {
+			break
+		}
+		if err := replaceReviewer(pr, review.ReviewerID, replacement.ID); err != nil {
+			return false, err
+		}
+		replaced := reviewerEvent(pr, review.ReviewerID, domain.ReviewerReplaced, now)
+		replaced.Reason = "review SLA exceeded"
+		events = append(events, replaced, reviewerEvent(pr, replacement.ID, domain.ReviewerAssigned, now))
+		action = domain.SLAReassign
+	}
+
+	if action == domain.SLANotify {
+		notifyOverdueReview(ctx, pr, review)
+	}
+	escalation := reviewerEvent(pr, review.ReviewerID, domain.ReviewerEscalated, now)
+	escalation.Reason = string(action)
+	events = append([]domain.ReviewerEvent{escalation}, events...)
+
+	err = s.repo.SavePR(ctx, pr, events)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// notifyOverdueReview is the reminder sent to a reviewer who missed the SLA.
+// The service has no messaging integration, so the reminder goes to the log.
+func notifyOverdueReview(ctx context.Context, pr *domain.PullRequest, review domain.PendingReview) {
+	slog.InfoContext(ctx, "reminder: review is overdue",
+		"reviewer_id", review.ReviewerID, "pull_request_id", pr.ID, "assigned_at", review.AssignedAt.Format(time.RFC3339))
+}
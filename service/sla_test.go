@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/J0hnLenin/ReviewRequest/domain"
+	"github.com/J0hnLenin/ReviewRequest/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestValidSLAPolicy(t *testing.T) {
+	assert.True(t, validSLAPolicy(domain.SLAPolicy{}))
+	assert.True(t, validSLAPolicy(domain.SLAPolicy{Hours: 8, Action: domain.SLAReassign}))
+	assert.False(t, validSLAPolicy(domain.SLAPolicy{Hours: -1}))
+	assert.False(t, validSLAPolicy(domain.SLAPolicy{Hours: 8, Action: "page"}))
+}
+
+func slaFixture(action domain.SLAAction) (*domain.PullRequest, *domain.Team, domain.PendingReview) {
+	pr, team := manualReviewerFixture()
+	team.SLA = domain.SLAPolicy{Hours: 24, Action: action}
+	review := domain.PendingReview{
+		PullRequestID: pr.ID,
+		ReviewerID:    "reviewer",
+		AssignedAt:    time.Now().Add(-48 * time.Hour),
+	}
+	return pr, team, review
+}
+
+func TestEscalateOverdueReviews_SkipsWithoutLock(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+
+	mockRepo.On("TryAdvisoryLock", mock.Anything, slaLockKey).Return(nil, false, nil)
+
+	// Act
+	escalated, err := service.EscalateOverdueReviews(context.Background(), time.Now())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Zero(t, escalated)
+	mockRepo.AssertNotCalled(t, "GetOverdueReviews")
+}
+
+func TestEscalateOverdueReviews_Actions(t *testing.T) {
+	testCases := []struct {
+		name          string
+		action        domain.SLAAction
+		reviewers     []string
+		expectedEvent domain.SLAAction
+		expected      []string
+	}{
+		{"Notify", domain.SLANotify, []string{"reviewer"}, domain.SLANotify, []string{"reviewer"}},
+		{"Add reviewer", domain.SLAAddReviewer, []string{"reviewer"}, domain.SLAAddReviewer, []string{"reviewer", "candidate"}},
+		{"Add reviewer without free slot", domain.SLAAddReviewer, []string{"reviewer", "inactive"}, domain.SLANotify, []string{"reviewer", "inactive"}},
+		{"Reassign", domain.SLAReassign, []string{"reviewer"}, domain.SLAReassign, []string{"candidate"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := &mocks.MockRepository{}
+
+			service := NewService(mockRepo)
+			pr, team, review := slaFixture(tc.action)
+			pr.ReviewersID = tc.reviewers
+			unlocked := false
+
+			mockRepo.On("TryAdvisoryLock", mock.Anything, slaLockKey).Return(func() { unlocked = true }, true, nil)
+			mockRepo.On("GetOverdueReviews", mock.Anything, mock.Anything).Return([]domain.PendingReview{review}, nil)
+			mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, team, nil)
+			mockRepo.On("SavePR", mock.Anything, pr, mock.MatchedBy(func(events []domain.ReviewerEvent) bool {
+				return len(events) > 0 &&
+					events[0].UserID == "reviewer" &&
+					events[0].Action == domain.ReviewerEscalated &&
+					events[0].Reason == string(tc.expectedEvent)
+			})).Return(nil)
+
+			// Act
+			escalated, err := service.EscalateOverdueReviews(context.Background(), time.Now())
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, 1, escalated)
+			assert.Equal(t, tc.expected, pr.ReviewersID)
+			assert.True(t, unlocked)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEscalateOverdueReviews_CrossTeamReviewerFollowsAuthorTeamPolicy(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	pr, team, review := slaFixture(domain.SLAReassign)
+	pr.ExtraTeams = []string{"design"}
+	pr.ReviewersID = []string{"reviewer", "design1"}
+	pr.ReviewerTeams = map[string]string{"reviewer": team.Name, "design1": "design"}
+	review.ReviewerID = "design1"
+
+	mockRepo.On("TryAdvisoryLock", mock.Anything, slaLockKey).Return(func() {}, true, nil)
+	mockRepo.On("GetOverdueReviews", mock.Anything, mock.Anything).Return([]domain.PendingReview{review}, nil)
+	mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, team, nil)
+	mockRepo.On("GetTeamByName", mock.Anything, "design").Return(crossTeam(), nil)
+	mockRepo.On("SavePR", mock.Anything, pr, mock.MatchedBy(func(events []domain.ReviewerEvent) bool {
+		return len(events) == 3 &&
+			events[0].UserID == "design1" &&
+			events[0].Reason == string(domain.SLAReassign)
+	})).Return(nil)
+
+	// Act
+	escalated, err := service.EscalateOverdueReviews(context.Background(), time.Now())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, escalated)
+	assert.Equal(t, []string{"reviewer", "design2"}, pr.ReviewersID)
+	assert.Equal(t, "design", pr.ReviewerTeams["design2"])
+	mockRepo.AssertExpectations(t)
+}
+
+func TestEscalateOverdueReviews_AddReviewerOnCrossTeamPR(t *testing.T) {
+	testCases := []struct {
+		name          string
+		reviewers     []string
+		expectedEvent domain.SLAAction
+		expected      []string
+	}{
+		{"Author team slot free", []string{"design1"}, domain.SLAAddReviewer, []string{"design1", "candidate"}},
+		{"Author team slot taken", []string{"reviewer", "design1"}, domain.SLANotify, []string{"reviewer", "design1"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := &mocks.MockRepository{}
+
+			service := NewService(mockRepo)
+			pr, team, review := slaFixture(domain.SLAAddReviewer)
+			pr.ExtraTeams = []string{"design"}
+			pr.ReviewersID = tc.reviewers
+			pr.ReviewerTeams = map[string]string{"reviewer": team.Name, "design1": "design"}
+			team.Members[1].IsActive = false
+			review.ReviewerID = "design1"
+
+			mockRepo.On("TryAdvisoryLock", mock.Anything, slaLockKey).Return(func() {}, true, nil)
+			mockRepo.On("GetOverdueReviews", mock.Anything, mock.Anything).Return([]domain.PendingReview{review}, nil)
+			mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, team, nil)
+			mockRepo.On("GetTeamByName", mock.Anything, "design").Return(crossTeam(), nil)
+			mockRepo.On("SavePR", mock.Anything, pr, mock.MatchedBy(func(events []domain.ReviewerEvent) bool {
+				return len(events) > 0 && events[0].Reason == string(tc.expectedEvent)
+			})).Return(nil)
+
+			// Act
+			escalated, err := service.EscalateOverdueReviews(context.Background(), time.Now())
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, 1, escalated)
+			assert.Equal(t, tc.expected, pr.ReviewersID)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEscalateOverdueReviews_SkipsMergedPR(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	pr, team, review := slaFixture(domain.SLANotify)
+	pr.Status = domain.Merged
+
+	mockRepo.On("TryAdvisoryLock", mock.Anything, slaLockKey).Return(func() {}, true, nil)
+	mockRepo.On("GetOverdueReviews", mock.Anything, mock.Anything).Return([]domain.PendingReview{review}, nil)
+	mockRepo.On("GetPRAndTeam", mock.Anything, pr.ID).Return(pr, team, nil)
+
+	// Act
+	escalated, err := service.EscalateOverdueReviews(context.Background(), time.Now())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Zero(t, escalated)
+	mockRepo.AssertNotCalled(t, "SavePR")
+}
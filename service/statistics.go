@@ -2,10 +2,219 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
 
 	"github.com/J0hnLenin/ReviewRequest/domain"
 )
 
-func (s *Service) GetStatistics(ctx context.Context) (*domain.Statistics, error) {
-	return s.repo.GetStatistics(ctx)
-}
\ No newline at end of file
+// GetStatistics counts open PRs by creation time and merged PRs by merge
+// time within period.
+func (s *Service) GetStatistics(ctx context.Context, period domain.TimeRange) (*domain.Statistics, error) {
+	stats, err := s.repo.GetStatistics(ctx, period)
+	if err != nil {
+		return nil, err
+	}
+	loads, err := s.repo.GetOpenReviewLoads(ctx, period)
+	if err != nil {
+		return nil, err
+	}
+	distribution := loadDistribution(loads)
+	stats.LoadDistribution = &distribution
+	return stats, nil
+}
+
+func loadDistribution(loads []int) domain.LoadDistribution {
+	sorted := slices.Clone(loads)
+	slices.Sort(sorted)
+
+	distribution := domain.LoadDistribution{
+		Users:     len(sorted),
+		Histogram: []domain.LoadBucket{},
+	}
+	samples := make([]float64, len(sorted))
+	for i, load := range sorted {
+		samples[i] = float64(load)
+		last := len(distribution.Histogram) - 1
+		if last >= 0 && distribution.Histogram[last].OpenReviews == load {
+			distribution.Histogram[last].Users++
+		} else {
+			distribution.Histogram = append(distribution.Histogram, domain.LoadBucket{OpenReviews: load, Users: 1})
+		}
+	}
+	if len(sorted) > 0 {
+		distribution.Max = sorted[len(sorted)-1]
+	}
+	distribution.P50 = percentile(samples, 0.5)
+	distribution.P90 = percentile(samples, 0.9)
+	distribution.P99 = percentile(samples, 0.99)
+	distribution.Gini = gini(sorted)
+	return distribution
+}
+
+// gini computes the Gini coefficient of sorted non-negative loads. It is 0
+// for no users or no load at all.
+func gini(sorted []int) float64 {
+	n := len(sorted)
+	total, weighted := 0, 0
+	for i, load := range sorted {
+		total += load
+		weighted += (i + 1) * load
+	}
+	if total == 0 {
+		return 0
+	}
+	return 2*float64(weighted)/(float64(n)*float64(total)) - float64(n+1)/float64(n)
+}
+
+// ParseTimeRange reads the from/to bounds (RFC 3339 or YYYY-MM-DD) and a
+// relative window such as "12h", "7d" or "4w" ending at to or now. A date-only
+// to covers that whole day.
+func ParseTimeRange(from, to, window string, now time.Time) (domain.TimeRange, error) {
+	var period domain.TimeRange
+	if from != "" && window != "" {
+		return period, fmt.Errorf("%w: from and window are mutually exclusive", domain.ErrInvalidTimeRange)
+	}
+	if from != "" {
+		t, err := parseTimeBound(from, false)
+		if err != nil {
+			return period, err
+		}
+		period.From = &t
+	}
+	if to != "" {
+		t, err := parseTimeBound(to, true)
+		if err != nil {
+			return period, err
+		}
+		period.To = &t
+	}
+	if window != "" {
+		length, err := parseWindow(window)
+		if err != nil {
+			return period, err
+		}
+		end := now
+		if period.To != nil {
+			end = *period.To
+		}
+		start := end.Add(-length)
+		period.From = &start
+	}
+	if period.From != nil && period.To != nil && !period.From.Before(*period.To) {
+		return period, fmt.Errorf("%w: from must be before to", domain.ErrInvalidTimeRange)
+	}
+	return period, nil
+}
+
+func parseTimeBound(value string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q is not a date", domain.ErrInvalidTimeRange, value)
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+var windowUnits = map[byte]time.Duration{
+	'h': time.Hour,
+	'd': 24 * time.Hour,
+	'w': 7 * 24 * time.Hour,
+}
+
+func parseWindow(window string) (time.Duration, error) {
+	unit, ok := windowUnits[window[len(window)-1]]
+	n, err := strconv.Atoi(window[:len(window)-1])
+	if !ok || err != nil || n <= 0 {
+		return 0, fmt.Errorf("%w: window %q", domain.ErrInvalidTimeRange, window)
+	}
+	return time.Duration(n) * unit, nil
+}
+
+const maxLeaderboardLimit = 1000
+
+// GetLeaderboard ranks users by metric within period. Open reviews and
+// authored PRs count by creation time, closed reviews by merge time.
+func (s *Service) GetLeaderboard(ctx context.Context, metric domain.LeaderboardMetric, period domain.TimeRange, limit int, offset int) (*domain.Leaderboard, error) {
+	switch metric {
+	case domain.LeaderboardOpenReviews, domain.LeaderboardClosedReviews, domain.LeaderboardAuthored:
+	default:
+		return nil, fmt.Errorf("%w: unknown metric %q", domain.ErrInvalidLeaderboard, metric)
+	}
+	if limit < 1 || limit > maxLeaderboardLimit || offset < 0 {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d, offset not negative", domain.ErrInvalidLeaderboard, maxLeaderboardLimit)
+	}
+
+	entries, total, err := s.repo.GetLeaderboard(ctx, metric, period, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		entries = []domain.LeaderboardEntry{}
+	}
+	return &domain.Leaderboard{
+		Metric:  metric,
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+		Entries: entries,
+	}, nil
+}
+
+// GetUserProfile counts a user's PRs and reviews within period. Open PRs
+// count by creation time, merged ones by merge time, and reassignments and
+// declines by when they happened.
+func (s *Service) GetUserProfile(ctx context.Context, userID string, period domain.TimeRange) (*domain.UserProfile, error) {
+	profile, err := s.repo.GetUserProfile(ctx, userID, period)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, domain.ErrNotFound
+	}
+	profile.Count = profile.ReviewsOpen + profile.ReviewsCompleted
+	return profile, nil
+}
+
+// GetTeamStatistics adds the average load and the most and least loaded
+// active members to the counts stored for the team. Ties go to the member
+// with the smallest ID.
+func (s *Service) GetTeamStatistics(ctx context.Context, teamName string) (*domain.TeamStatistics, error) {
+	stats, err := s.repo.GetTeamStatistics(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if stats == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	total, active := 0, 0
+	loads := make([]int, 0, len(stats.MemberLoad))
+	for i := range stats.MemberLoad {
+		load := &stats.MemberLoad[i]
+		if !load.IsActive {
+			continue
+		}
+		total += load.Count
+		active++
+		loads = append(loads, load.Count)
+		if stats.MostLoaded == nil || load.Count > stats.MostLoaded.Count {
+			stats.MostLoaded = &load.UserStats
+		}
+		if stats.LeastLoaded == nil || load.Count < stats.LeastLoaded.Count {
+			stats.LeastLoaded = &load.UserStats
+		}
+	}
+	if active > 0 {
+		stats.AvgReviewsPerMember = float64(total) / float64(active)
+	}
+	stats.LoadDistribution = loadDistribution(loads)
+	return stats, nil
+}
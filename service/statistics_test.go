@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/J0hnLenin/ReviewRequest/domain"
+	"github.com/J0hnLenin/ReviewRequest/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func memberLoad(id string, count int, active bool) domain.MemberLoad {
+	return domain.MemberLoad{
+		UserStats: domain.UserStats{UserID: id, UserName: id, Count: count},
+		IsActive:  active,
+	}
+}
+
+func TestGetTeamStatistics_Success(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+
+	mockRepo.On("GetTeamStatistics", mock.Anything, "team1").Return(&domain.TeamStatistics{
+		TeamName:  "team1",
+		OpenPRs:   3,
+		MergedPRs: 5,
+		MemberLoad: []domain.MemberLoad{
+			memberLoad("user1", 2, true),
+			memberLoad("user2", 0, true),
+			memberLoad("user3", 2, true),
+			memberLoad("user4", 9, false),
+			memberLoad("user5", 0, true),
+		},
+	}, nil)
+
+	// Act
+	stats, err := service.GetTeamStatistics(context.Background(), "team1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, stats.AvgReviewsPerMember)
+	assert.Equal(t, "user1", stats.MostLoaded.UserID)
+	assert.Equal(t, "user2", stats.LeastLoaded.UserID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetTeamStatistics_NoActiveMembers(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+
+	mockRepo.On("GetTeamStatistics", mock.Anything, "team1").Return(&domain.TeamStatistics{
+		TeamName:   "team1",
+		MemberLoad: []domain.MemberLoad{memberLoad("user1", 1, false)},
+	}, nil)
+
+	// Act
+	stats, err := service.GetTeamStatistics(context.Background(), "team1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Zero(t, stats.AvgReviewsPerMember)
+	assert.Nil(t, stats.MostLoaded)
+	assert.Nil(t, stats.LeastLoaded)
+}
+
+func TestGetTeamStatistics_TeamNotFound(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+
+	mockRepo.On("GetTeamStatistics", mock.Anything, "missing").Return(nil, nil)
+
+	// Act
+	stats, err := service.GetTeamStatistics(context.Background(), "missing")
+
+	// Assert
+	assert.Nil(t, stats)
+	assert.Equal(t, domain.ErrNotFound, err)
+}
+
+func TestParseTimeRange(t *testing.T) {
+	now := time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC)
+	date := func(day int) time.Time { return time.Date(2025, 3, day, 0, 0, 0, 0, time.UTC) }
+
+	testCases := []struct {
+		name             string
+		from, to, window string
+		wantFrom         *time.Time
+		wantTo           *time.Time
+	}{
+		{"unbounded", "", "", "", nil, nil},
+		{"dates", "2025-03-01", "2025-03-10", "", ptr(date(1)), ptr(date(11))},
+		{"rfc3339", "2025-03-01T10:00:00Z", "", "", ptr(date(1).Add(10 * time.Hour)), nil},
+		{"window from now", "", "", "7d", ptr(now.AddDate(0, 0, -7)), nil},
+		{"window before to", "", "2025-03-10", "1w", ptr(date(4)), ptr(date(11))},
+		{"window in hours", "", "", "12h", ptr(now.Add(-12 * time.Hour)), nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			period, err := ParseTimeRange(tc.from, tc.to, tc.window, now)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantFrom, period.From)
+			assert.Equal(t, tc.wantTo, period.To)
+		})
+	}
+}
+
+func TestParseTimeRange_Invalid(t *testing.T) {
+	testCases := []struct {
+		name             string
+		from, to, window string
+	}{
+		{"bad date", "yesterday", "", ""},
+		{"from after to", "2025-03-10", "2025-03-01", ""},
+		{"from with window", "2025-03-01", "", "7d"},
+		{"unknown unit", "", "", "7y"},
+		{"zero window", "", "", "0d"},
+		{"unit only", "", "", "d"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			_, err := ParseTimeRange(tc.from, tc.to, tc.window, time.Now())
+
+			// Assert
+			assert.ErrorIs(t, err, domain.ErrInvalidTimeRange)
+		})
+	}
+}
+
+func ptr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestGetLeaderboard_Success(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	entries := []domain.LeaderboardEntry{
+		{Rank: 1, UserStats: domain.UserStats{UserID: "user1", Count: 5}},
+		{Rank: 1, UserStats: domain.UserStats{UserID: "user2", Count: 5}},
+		{Rank: 3, UserStats: domain.UserStats{UserID: "user3", Count: 2}},
+	}
+
+	mockRepo.On("GetLeaderboard", mock.Anything, domain.LeaderboardClosedReviews, domain.TimeRange{}, 3, 0).Return(entries, 7, nil)
+
+	// Act
+	leaderboard, err := service.GetLeaderboard(context.Background(), domain.LeaderboardClosedReviews, domain.TimeRange{}, 3, 0)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 7, leaderboard.Total)
+	assert.Equal(t, entries, leaderboard.Entries)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetLeaderboard_EmptyPage(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+
+	mockRepo.On("GetLeaderboard", mock.Anything, domain.LeaderboardAuthored, domain.TimeRange{}, 10, 20).Return(nil, 4, nil)
+
+	// Act
+	leaderboard, err := service.GetLeaderboard(context.Background(), domain.LeaderboardAuthored, domain.TimeRange{}, 10, 20)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 4, leaderboard.Total)
+	assert.NotNil(t, leaderboard.Entries)
+	assert.Empty(t, leaderboard.Entries)
+}
+
+func TestGetLeaderboard_Invalid(t *testing.T) {
+	testCases := []struct {
+		name   string
+		metric domain.LeaderboardMetric
+		limit  int
+		offset int
+	}{
+		{"unknown metric", "karma", 10, 0},
+		{"empty metric", "", 10, 0},
+		{"zero limit", domain.LeaderboardOpenReviews, 0, 0},
+		{"limit too large", domain.LeaderboardOpenReviews, 1001, 0},
+		{"negative offset", domain.LeaderboardOpenReviews, 10, -1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := &mocks.MockRepository{}
+
+			service := NewService(mockRepo)
+
+			// Act
+			leaderboard, err := service.GetLeaderboard(context.Background(), tc.metric, domain.TimeRange{}, tc.limit, tc.offset)
+
+			// Assert
+			assert.Nil(t, leaderboard)
+			assert.ErrorIs(t, err, domain.ErrInvalidLeaderboard)
+			mockRepo.AssertNotCalled(t, "GetLeaderboard")
+		})
+	}
+}
+
+func TestGetUserProfile_Success(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	avg := 7200.0
+	profile := &domain.UserProfile{
+		UserStats:             domain.UserStats{UserID: "user1", UserName: "Ivan"},
+		AuthoredOpen:          1,
+		ReviewsOpen:           2,
+		ReviewsCompleted:      4,
+		Declined:              1,
+		AvgTimeToMergeSeconds: &avg,
+	}
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	period := domain.TimeRange{From: &from}
+
+	mockRepo.On("GetUserProfile", mock.Anything, "user1", period).Return(profile, nil)
+
+	// Act
+	result, err := service.GetUserProfile(context.Background(), "user1", period)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, profile, result)
+	assert.Equal(t, 6, result.Count)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetUserProfile_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+
+	mockRepo.On("GetUserProfile", mock.Anything, "missing", domain.TimeRange{}).Return(nil, nil)
+
+	// Act
+	result, err := service.GetUserProfile(context.Background(), "missing", domain.TimeRange{})
+
+	// Assert
+	assert.Nil(t, result)
+	assert.Equal(t, domain.ErrNotFound, err)
+}
+
+func TestLoadDistribution(t *testing.T) {
+	// Act
+	distribution := loadDistribution([]int{3, 0, 1, 0})
+
+	// Assert
+	assert.Equal(t, 4, distribution.Users)
+	assert.Equal(t, []domain.LoadBucket{
+		{OpenReviews: 0, Users: 2},
+		{OpenReviews: 1, Users: 1},
+		{OpenReviews: 3, Users: 1},
+	}, distribution.Histogram)
+	assert.Equal(t, 3, distribution.Max)
+	assert.InDelta(t, 0.5, distribution.P50, 1e-9)
+	assert.InDelta(t, 0.625, distribution.Gini, 1e-9)
+}
+
+func TestGini(t *testing.T) {
+	assert.Equal(t, 0.0, gini(nil))
+	assert.Equal(t, 0.0, gini([]int{0, 0, 0}))
+	assert.InDelta(t, 0.0, gini([]int{2, 2, 2, 2}), 1e-9)
+	assert.InDelta(t, 0.75, gini([]int{0, 0, 0, 8}), 1e-9)
+}
+
+func TestGetStatistics_AddsLoadDistribution(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+
+	mockRepo.On("GetStatistics", mock.Anything, domain.TimeRange{}).Return(&domain.Statistics{TotalOpenPRs: 2}, nil)
+	mockRepo.On("GetOpenReviewLoads", mock.Anything, domain.TimeRange{}).Return([]int{2, 2}, nil)
+
+	// Act
+	stats, err := service.GetStatistics(context.Background(), domain.TimeRange{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, stats.TotalOpenPRs)
+	assert.Equal(t, 2, stats.LoadDistribution.Users)
+	assert.Zero(t, stats.LoadDistribution.Gini)
+	mockRepo.AssertExpectations(t)
+}
@@ -7,6 +7,20 @@ import (
 )
 
 func (s *Service) TeamSave(ctx context.Context, t *domain.Team) error {
+	for _, member := range t.Members {
+		if !validWorkingHours(member.WorkingHours) {
+			return domain.ErrInvalidWorkingHours
+		}
+		if !validRole(member.Role) {
+			return domain.ErrInvalidRole
+		}
+		if !validReviewWeight(member.ReviewWeight) {
+			return domain.ErrInvalidWeight
+		}
+	}
+	if !validRequirements(t.RoleRequirements) {
+		return domain.ErrInvalidRequirements
+	}
 	team, err := s.repo.GetTeamByName(ctx, t.Name)
 	if err != nil {
 		return err
@@ -14,7 +28,12 @@ func (s *Service) TeamSave(ctx context.Context, t *domain.Team) error {
 	if team != nil {
 		return domain.ErrTeamExists
 	}
-	return s.repo.SaveTeam(ctx, t)
+	err = s.repo.SaveTeam(ctx, t)
+	if err != nil {
+		return err
+	}
+	s.scheduleTopUp(t.Name)
+	return nil
 }
 
 func (s *Service) TeamGetByName(ctx context.Context, n string) (*domain.Team, error) {
@@ -36,5 +55,65 @@ func (s *Service) TeamChangeActive(ctx context.Context, name string, newValue bo
 	if team == nil {
 		return nil, domain.ErrNotFound
 	}
+	if newValue {
+		s.scheduleTopUp(name)
+	}
+	return team, nil
+}
+
+func (s *Service) TeamSetOwnerRules(ctx context.Context, name string, rules []domain.OwnerRule) (*domain.Team, error) {
+	team, err := s.repo.GetTeamByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, domain.ErrNotFound
+	}
+	err = s.repo.SaveTeamOwnerRules(ctx, name, rules)
+	if err != nil {
+		return nil, err
+	}
+	team.OwnerRules = rules
+	return team, nil
+}
+
+func (s *Service) TeamSetSLA(ctx context.Context, name string, policy domain.SLAPolicy) (*domain.Team, error) {
+	if !validSLAPolicy(policy) {
+		return nil, domain.ErrInvalidSLA
+	}
+	if policy.Hours > 0 && policy.Action == "" {
+		policy.Action = domain.SLANotify
+	}
+	team, err := s.repo.GetTeamByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, domain.ErrNotFound
+	}
+	err = s.repo.SaveTeamSLA(ctx, name, policy)
+	if err != nil {
+		return nil, err
+	}
+	team.SLA = policy
 	return team, nil
-}
\ No newline at end of file
+}
+
+func (s *Service) TeamSetRoleRequirements(ctx context.Context, name string, requirements []domain.RoleRequirement) (*domain.Team, error) {
+	if !validRequirements(requirements) {
+		return nil, domain.ErrInvalidRequirements
+	}
+	team, err := s.repo.GetTeamByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, domain.ErrNotFound
+	}
+	err = s.repo.SaveTeamRoleRequirements(ctx, name, requirements)
+	if err != nil {
+		return nil, err
+	}
+	team.RoleRequirements = requirements
+	return team, nil
+}
@@ -475,4 +475,146 @@ func TestChangeTeamActive_ConnectionError(t *testing.T) {
     assert.Equal(t, connectionError, err)
 
     mockRepo.AssertExpectations(t)
-}
\ No newline at end of file
+}
+
+func TestTeamSetOwnerRules_Success(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	teamName := "backend"
+	existingTeam := &domain.Team{
+		Name: teamName,
+		Members: []*domain.User{
+			{ID: "user1", Name: "User One", TeamName: teamName, IsActive: true},
+		},
+	}
+	rules := []domain.OwnerRule{
+		{Pattern: "*.sql", Tags: []string{"db"}},
+	}
+
+	mockRepo.On("GetTeamByName", mock.Anything, teamName).Return(existingTeam, nil)
+	mockRepo.On("SaveTeamOwnerRules", mock.Anything, teamName, rules).Return(nil)
+
+	// Act
+	team, err := service.TeamSetOwnerRules(context.Background(), teamName, rules)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, rules, team.OwnerRules)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTeamSetOwnerRules_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+
+	mockRepo.On("GetTeamByName", mock.Anything, "ghosts").Return(nil, nil)
+
+	// Act
+	team, err := service.TeamSetOwnerRules(context.Background(), "ghosts", nil)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, team)
+	assert.Equal(t, domain.ErrNotFound, err)
+	mockRepo.AssertNotCalled(t, "SaveTeamOwnerRules")
+}
+
+func TestTeamSave_InvalidRole(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	team := &domain.Team{
+		Name: "backend",
+		Members: []*domain.User{
+			{ID: "user1", Name: "User One", TeamName: "backend", IsActive: true, Role: "principal"},
+		},
+	}
+
+	// Act
+	err := service.TeamSave(context.Background(), team)
+
+	// Assert
+	assert.Equal(t, domain.ErrInvalidRole, err)
+	mockRepo.AssertNotCalled(t, "SaveTeam")
+}
+
+func TestTeamSave_RequirementsExceedReviewerLimit(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	team := &domain.Team{
+		Name: "backend",
+		RoleRequirements: []domain.RoleRequirement{
+			{MinRole: domain.RoleSenior, Count: 2},
+			{MinRole: domain.RoleJunior, Count: 1},
+		},
+	}
+
+	// Act
+	err := service.TeamSave(context.Background(), team)
+
+	// Assert
+	assert.Equal(t, domain.ErrInvalidRequirements, err)
+	mockRepo.AssertNotCalled(t, "SaveTeam")
+}
+
+func TestTeamSetRoleRequirements_Success(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	teamName := "backend"
+	requirements := []domain.RoleRequirement{{MinRole: domain.RoleSenior, Count: 1}}
+
+	mockRepo.On("GetTeamByName", mock.Anything, teamName).Return(&domain.Team{Name: teamName}, nil)
+	mockRepo.On("SaveTeamRoleRequirements", mock.Anything, teamName, requirements).Return(nil)
+
+	// Act
+	team, err := service.TeamSetRoleRequirements(context.Background(), teamName, requirements)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, requirements, team.RoleRequirements)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTeamSetSLA_DefaultsToNotify(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	teamName := "backend"
+	expected := domain.SLAPolicy{Hours: 24, Action: domain.SLANotify}
+
+	mockRepo.On("GetTeamByName", mock.Anything, teamName).Return(&domain.Team{Name: teamName}, nil)
+	mockRepo.On("SaveTeamSLA", mock.Anything, teamName, expected).Return(nil)
+
+	// Act
+	team, err := service.TeamSetSLA(context.Background(), teamName, domain.SLAPolicy{Hours: 24})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, expected, team.SLA)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTeamSetSLA_Invalid(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+
+	// Act
+	team, err := service.TeamSetSLA(context.Background(), "backend", domain.SLAPolicy{Hours: 24, Action: "page"})
+
+	// Assert
+	assert.Nil(t, team)
+	assert.Equal(t, domain.ErrInvalidSLA, err)
+	mockRepo.AssertNotCalled(t, "SaveTeamSLA")
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/J0hnLenin/ReviewRequest/domain"
+)
+
+const maxTimeSeriesPoints = 1000
+
+var timeSeriesMetrics = []domain.TimeSeriesMetric{
+	domain.SeriesPRsCreated,
+	domain.SeriesPRsMerged,
+	domain.SeriesReviewsAssigned,
+}
+
+// GetTimeSeries counts PRs created, PRs merged and reviews assigned per
+// bucket, or only metric when it is set. Every bucket between the period
+// bounds (or the first and last event when unbounded) is present, empty
+// ones with a zero count.
+func (s *Service) GetTimeSeries(ctx context.Context, metric domain.TimeSeriesMetric, bucket domain.TimeBucket, teamName string, period domain.TimeRange) (*domain.TimeSeries, error) {
+	if _, ok := bucketSteps[bucket]; !ok {
+		return nil, fmt.Errorf("%w: unknown bucket %q", domain.ErrInvalidTimeSeries, bucket)
+	}
+	metrics := timeSeriesMetrics
+	if metric != "" {
+		if !slices.Contains(timeSeriesMetrics, metric) {
+			return nil, fmt.Errorf("%w: unknown metric %q", domain.ErrInvalidTimeSeries, metric)
+		}
+		metrics = []domain.TimeSeriesMetric{metric}
+	}
+	if teamName != "" {
+		team, err := s.repo.GetTeamByName(ctx, teamName)
+		if err != nil {
+			return nil, err
+		}
+		if team == nil {
+			return nil, domain.ErrNotFound
+		}
+	}
+
+	series := &domain.TimeSeries{
+		Bucket:   bucket,
+		TeamName: teamName,
+		Series:   make(map[domain.TimeSeriesMetric][]domain.TimeSeriesPoint, len(metrics)),
+	}
+	for _, m := range metrics {
+		points, err := s.repo.GetTimeSeries(ctx, m, bucket, teamName, period)
+		if err != nil {
+			return nil, err
+		}
+		filled, err := fillBuckets(points, bucket, period)
+		if err != nil {
+			return nil, err
+		}
+		series.Series[m] = filled
+	}
+	return series, nil
+}
+
+// bucketSteps advances a bucket start to the next bucket.
+var bucketSteps = map[domain.TimeBucket]func(time.Time) time.Time{
+	domain.BucketDay:   func(t time.Time) time.Time { return t.AddDate(0, 0, 1) },
+	domain.BucketWeek:  func(t time.Time) time.Time { return t.AddDate(0, 0, 7) },
+	domain.BucketMonth: func(t time.Time) time.Time { return t.AddDate(0, 1, 0) },
+}
+
+// truncateToBucket mirrors Postgres date_trunc in UTC; weeks start on Monday.
+func truncateToBucket(t time.Time, bucket domain.TimeBucket) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch bucket {
+	case domain.BucketWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case domain.BucketMonth:
+		return day.AddDate(0, 0, 1-day.Day())
+	}
+	return day
+}
+
+func fillBuckets(points []domain.TimeSeriesPoint, bucket domain.TimeBucket, period domain.TimeRange) ([]domain.TimeSeriesPoint, error) {
+	counts := make(map[time.Time]int, len(points))
+	var first, last time.Time
+	for i, p := range points {
+		start := truncateToBucket(p.Start, bucket)
+		counts[start] += p.Count
+		if i == 0 || start.Before(first) {
+			first = start
+		}
+		if i == 0 || start.After(last) {
+			last = start
+		}
+	}
+	if period.From != nil {
+		first = truncateToBucket(*period.From, bucket)
+	}
+	if period.To != nil {
+		last = truncateToBucket(period.To.Add(-time.Nanosecond), bucket)
+	}
+
+	filled := []domain.TimeSeriesPoint{}
+	if first.IsZero() || last.IsZero() {
+		return filled, nil
+	}
+	next := bucketSteps[bucket]
+	for start := first; !start.After(last); start = next(start) {
+		if len(filled) == maxTimeSeriesPoints {
+			return nil, fmt.Errorf("%w: more than %d buckets, narrow the period", domain.ErrInvalidTimeSeries, maxTimeSeriesPoints)
+		}
+		filled = append(filled, domain.TimeSeriesPoint{Start: start, Count: counts[start]})
+	}
+	return filled, nil
+}
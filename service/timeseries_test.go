@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/J0hnLenin/ReviewRequest/domain"
+	"github.com/J0hnLenin/ReviewRequest/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func day(month time.Month, d int) time.Time {
+	return time.Date(2025, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestTruncateToBucket(t *testing.T) {
+	// Wednesday afternoon
+	ts := time.Date(2025, 3, 12, 15, 30, 0, 0, time.UTC)
+	sunday := time.Date(2025, 3, 16, 23, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, day(3, 12), truncateToBucket(ts, domain.BucketDay))
+	assert.Equal(t, day(3, 10), truncateToBucket(ts, domain.BucketWeek))
+	assert.Equal(t, day(3, 10), truncateToBucket(sunday, domain.BucketWeek))
+	assert.Equal(t, day(3, 1), truncateToBucket(ts, domain.BucketMonth))
+}
+
+func TestFillBuckets(t *testing.T) {
+	points := []domain.TimeSeriesPoint{
+		{Start: day(3, 2), Count: 4},
+		{Start: day(3, 4), Count: 1},
+	}
+
+	// Act
+	unbounded, err := fillBuckets(points, domain.BucketDay, domain.TimeRange{})
+	assert.NoError(t, err)
+	from, to := day(3, 1), day(3, 6)
+	bounded, err := fillBuckets(points, domain.BucketDay, domain.TimeRange{From: &from, To: &to})
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, []domain.TimeSeriesPoint{
+		{Start: day(3, 2), Count: 4},
+		{Start: day(3, 3), Count: 0},
+		{Start: day(3, 4), Count: 1},
+	}, unbounded)
+	assert.Len(t, bounded, 5)
+	assert.Equal(t, day(3, 1), bounded[0].Start)
+	assert.Equal(t, day(3, 5), bounded[4].Start)
+}
+
+func TestFillBuckets_Empty(t *testing.T) {
+	filled, err := fillBuckets(nil, domain.BucketWeek, domain.TimeRange{})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, filled)
+	assert.Empty(t, filled)
+}
+
+func TestFillBuckets_TooMany(t *testing.T) {
+	from, to := day(1, 1), day(1, 1).AddDate(5, 0, 0)
+
+	_, err := fillBuckets(nil, domain.BucketDay, domain.TimeRange{From: &from, To: &to})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidTimeSeries)
+}
+
+func TestGetTimeSeries_AllMetricsForTeam(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+	points := []domain.TimeSeriesPoint{{Start: day(3, 3), Count: 2}}
+
+	mockRepo.On("GetTeamByName", mock.Anything, "team1").Return(simulationTeam(), nil)
+	mockRepo.On("GetTimeSeries", mock.Anything, mock.Anything, domain.BucketWeek, "team1", domain.TimeRange{}).Return(points, nil)
+
+	// Act
+	series, err := service.GetTimeSeries(context.Background(), "", domain.BucketWeek, "team1", domain.TimeRange{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, series.Series, 3)
+	assert.Equal(t, points, series.Series[domain.SeriesReviewsAssigned])
+	mockRepo.AssertNumberOfCalls(t, "GetTimeSeries", 3)
+}
+
+func TestGetTimeSeries_Invalid(t *testing.T) {
+	testCases := []struct {
+		name   string
+		metric domain.TimeSeriesMetric
+		bucket domain.TimeBucket
+	}{
+		{"unknown bucket", "", "year"},
+		{"unknown metric", "prs_closed", domain.BucketDay},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := &mocks.MockRepository{}
+
+			service := NewService(mockRepo)
+
+			// Act
+			series, err := service.GetTimeSeries(context.Background(), tc.metric, tc.bucket, "", domain.TimeRange{})
+
+			// Assert
+			assert.Nil(t, series)
+			assert.ErrorIs(t, err, domain.ErrInvalidTimeSeries)
+			mockRepo.AssertNotCalled(t, "GetTimeSeries")
+		})
+	}
+}
+
+func TestGetTimeSeries_TeamNotFound(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+
+	mockRepo.On("GetTeamByName", mock.Anything, "missing").Return(nil, nil)
+
+	// Act
+	series, err := service.GetTimeSeries(context.Background(), domain.SeriesPRsMerged, domain.BucketDay, "missing", domain.TimeRange{})
+
+	// Assert
+	assert.Nil(t, series)
+	assert.Equal(t, domain.ErrNotFound, err)
+}
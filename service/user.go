@@ -22,9 +22,50 @@ func (s *Service) UserChangeActive(ctx context.Context, id string, newValue bool
 	if err != nil {
 		return nil, err
 	}
+	if newValue {
+		s.scheduleTopUp(user.TeamName)
+	}
 	return user, nil
 }
 
 func (s *Service) UserGetReviews(ctx context.Context, id string) ([]*domain.PullRequest, error) {
 	return s.repo.GetPRByAuthor(ctx, id)
-}
\ No newline at end of file
+}
+
+func (s *Service) UserSetWorkingHours(ctx context.Context, id string, wh domain.WorkingHours) (*domain.User, error) {
+	if !validWorkingHours(wh) {
+		return nil, domain.ErrInvalidWorkingHours
+	}
+	user, err := s.repo.GetUserById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrNotFound
+	}
+	user.WorkingHours = wh
+	err = s.repo.SaveUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *Service) UserSetReviewWeight(ctx context.Context, id string, weight int) (*domain.User, error) {
+	if !validReviewWeight(&weight) {
+		return nil, domain.ErrInvalidWeight
+	}
+	user, err := s.repo.GetUserById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrNotFound
+	}
+	user.ReviewWeight = &weight
+	err = s.repo.SaveUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
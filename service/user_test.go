@@ -274,4 +274,108 @@ func TestUserGetReviews_NilResult(t *testing.T) {
 	assert.Nil(t, prs)
 
 	mockRepo.AssertExpectations(t)
-}
\ No newline at end of file
+}
+
+func TestUserSetWorkingHours_Success(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+
+	userID := "user123"
+	currentUser := &domain.User{
+		ID:       userID,
+		Name:     "Test User",
+		TeamName: "team1",
+		IsActive: true,
+	}
+	hours := domain.WorkingHours{TimeZone: "Asia/Yerevan", Start: 10 * 60, End: 19 * 60}
+
+	mockRepo.On("GetUserById", mock.Anything, userID).Return(currentUser, nil)
+	mockRepo.On("SaveUser", mock.Anything, mock.MatchedBy(func(user *domain.User) bool {
+		return user.ID == userID && user.WorkingHours == hours
+	})).Return(nil)
+
+	// Act
+	updatedUser, err := service.UserSetWorkingHours(context.Background(), userID, hours)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, hours, updatedUser.WorkingHours)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserSetWorkingHours_InvalidTimeZone(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+
+	hours := domain.WorkingHours{TimeZone: "Moscow", Start: 10 * 60, End: 19 * 60}
+
+	// Act
+	updatedUser, err := service.UserSetWorkingHours(context.Background(), "user123", hours)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, updatedUser)
+	assert.Equal(t, domain.ErrInvalidWorkingHours, err)
+	mockRepo.AssertNotCalled(t, "SaveUser")
+}
+
+func TestUserSetWorkingHours_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+
+	hours := domain.WorkingHours{TimeZone: "Europe/Berlin", Start: 9 * 60, End: 17 * 60}
+	mockRepo.On("GetUserById", mock.Anything, "ghost").Return(nil, nil)
+
+	// Act
+	updatedUser, err := service.UserSetWorkingHours(context.Background(), "ghost", hours)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, updatedUser)
+	assert.Equal(t, domain.ErrNotFound, err)
+	mockRepo.AssertNotCalled(t, "SaveUser")
+}
+
+func TestUserSetReviewWeight_Success(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+
+	userID := "user123"
+	currentUser := &domain.User{ID: userID, Name: "Test User", TeamName: "team1", IsActive: true}
+
+	mockRepo.On("GetUserById", mock.Anything, userID).Return(currentUser, nil)
+	mockRepo.On("SaveUser", mock.Anything, mock.MatchedBy(func(user *domain.User) bool {
+		return user.ID == userID && user.ReviewWeight != nil && *user.ReviewWeight == 0
+	})).Return(nil)
+
+	// Act
+	updatedUser, err := service.UserSetReviewWeight(context.Background(), userID, 0)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, *updatedUser.ReviewWeight)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserSetReviewWeight_Invalid(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockRepository{}
+
+	service := NewService(mockRepo)
+
+	// Act
+	updatedUser, err := service.UserSetReviewWeight(context.Background(), "user123", -1)
+
+	// Assert
+	assert.Nil(t, updatedUser)
+	assert.Equal(t, domain.ErrInvalidWeight, err)
+	mockRepo.AssertNotCalled(t, "GetUserById")
+}